@@ -3,15 +3,44 @@ package dasel
 
 import (
 	"context"
+	"fmt"
+	"slices"
+
 	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/model"
 )
 
-// Query queries the data using the selector and returns the results.
-func Query(ctx context.Context, data any, selector string, opts ...execution.ExecuteOptionFn) ([]*model.Value, int, error) {
-	options := execution.NewOptions(opts...)
+// Program is a selector that has been compiled ahead of time so that it can be run many times.
+// A Program is safe to run concurrently against different inputs.
+type Program struct {
+	program *execution.Program
+	opts    []execution.ExecuteOptionFn
+}
+
+// Compile parses and compiles the selector.
+// The given options are applied each time the program is run, before any options given to the run itself.
+func Compile(selector string, opts ...execution.ExecuteOptionFn) (*Program, error) {
+	program, err := execution.CompileSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{program: program, opts: opts}, nil
+}
+
+// execute runs the program against the data and returns the output.
+func (p *Program) execute(ctx context.Context, data any, opts []execution.ExecuteOptionFn) (*model.Value, error) {
+	options := execution.NewOptions(append(slices.Clone(p.opts), opts...)...)
 	val := model.NewValue(data)
-	out, err := execution.ExecuteSelector(ctx, selector, val, options)
+	out, err := p.program.Execute(ctx, val, options)
+	if err != nil {
+		return nil, fmt.Errorf("error executing selector: %w", err)
+	}
+	return out, nil
+}
+
+// Query runs the program against the data and returns the results.
+func (p *Program) Query(ctx context.Context, data any, opts ...execution.ExecuteOptionFn) ([]*model.Value, int, error) {
+	out, err := p.execute(ctx, data, opts)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -30,10 +59,10 @@ func Query(ctx context.Context, data any, selector string, opts ...execution.Exe
 	return []*model.Value{out}, 1, nil
 }
 
-// Select queries the data using the selector and returns the results as native Go types.
+// Select runs the program against the data and returns the results as native Go types.
 // Ordering within maps is not guaranteed.
-func Select(ctx context.Context, data any, selector string, opts ...execution.ExecuteOptionFn) (any, int, error) {
-	res, count, err := Query(ctx, data, selector, opts...)
+func (p *Program) Select(ctx context.Context, data any, opts ...execution.ExecuteOptionFn) (any, int, error) {
+	res, count, err := p.Query(ctx, data, opts...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -48,10 +77,35 @@ func Select(ctx context.Context, data any, selector string, opts ...execution.Ex
 	return out, count, err
 }
 
-// Modify runs the query against the given data and updates it in-place.
+// QueryInto runs the program against the data and decodes the result into the Go value pointed to by out.
+// If the program returns many results, e.g. from a branch or spread, they are decoded as a slice.
+// See model.Value.Decode for how values are decoded.
+func (p *Program) QueryInto(ctx context.Context, data any, out any, opts ...execution.ExecuteOptionFn) error {
+	res, err := p.execute(ctx, data, opts)
+	if err != nil {
+		return err
+	}
+
+	if res.IsBranch() || res.IsSpread() {
+		items := model.NewSliceValue()
+		if err := res.RangeSlice(func(_ int, v *model.Value) error {
+			return items.Append(v)
+		}); err != nil {
+			return err
+		}
+		res = items
+	}
+
+	if err := res.Decode(out); err != nil {
+		return fmt.Errorf("error decoding result: %w", err)
+	}
+	return nil
+}
+
+// Modify runs the program against the given data and updates it in-place.
 // Given data must be a pointer to a mutable data structure.
-func Modify(ctx context.Context, data any, selector string, newValue any, opts ...execution.ExecuteOptionFn) (int, error) {
-	res, count, err := Query(ctx, data, selector, opts...)
+func (p *Program) Modify(ctx context.Context, data any, newValue any, opts ...execution.ExecuteOptionFn) (int, error) {
+	res, count, err := p.Query(ctx, data, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -62,3 +116,43 @@ func Modify(ctx context.Context, data any, selector string, newValue any, opts .
 	}
 	return count, nil
 }
+
+// Query queries the data using the selector and returns the results.
+func Query(ctx context.Context, data any, selector string, opts ...execution.ExecuteOptionFn) ([]*model.Value, int, error) {
+	program, err := Compile(selector, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return program.Query(ctx, data)
+}
+
+// Select queries the data using the selector and returns the results as native Go types.
+// Ordering within maps is not guaranteed.
+func Select(ctx context.Context, data any, selector string, opts ...execution.ExecuteOptionFn) (any, int, error) {
+	program, err := Compile(selector, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return program.Select(ctx, data)
+}
+
+// QueryInto queries the data using the selector and decodes the result into the Go value pointed to by out.
+// If the query returns many results, e.g. from a branch or spread, they are decoded as a slice.
+// See model.Value.Decode for how values are decoded.
+func QueryInto(ctx context.Context, data any, selector string, out any, opts ...execution.ExecuteOptionFn) error {
+	program, err := Compile(selector, opts...)
+	if err != nil {
+		return err
+	}
+	return program.QueryInto(ctx, data, out)
+}
+
+// Modify runs the query against the given data and updates it in-place.
+// Given data must be a pointer to a mutable data structure.
+func Modify(ctx context.Context, data any, selector string, newValue any, opts ...execution.ExecuteOptionFn) (int, error) {
+	program, err := Compile(selector, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return program.Modify(ctx, data, newValue)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/tomwright/dasel/v3"
 	"github.com/tomwright/dasel/v3/execution"
+	_ "github.com/tomwright/dasel/v3/parsing/json"
 )
 
 func ExampleSelect() {
@@ -35,3 +36,48 @@ func ExampleSelect() {
 	// Alice
 	// Tom
 }
+
+func ExampleQueryInto() {
+	myData := map[string]any{
+		"users": []map[string]any{
+			{"name": "Alice", "age": 30},
+			{"name": "Bob", "age": 25},
+		},
+	}
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	var users []user
+	if err := dasel.QueryInto(context.Background(), myData, `users.filter(age > 27)`, &users); err != nil {
+		panic(err)
+	}
+	fmt.Printf("%+v\n", users)
+
+	// Output:
+	// [{Name:Alice Age:30}]
+}
+
+func ExampleParse() {
+	// Formats are registered by importing their packages, e.g. _ "github.com/tomwright/dasel/v3/parsing/json".
+	doc, err := dasel.Parse([]byte(`{"name": "Tom"}`), "json")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := doc.Set(context.Background(), "age", 31); err != nil {
+		panic(err)
+	}
+	out, err := doc.Bytes()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(out))
+
+	// Output:
+	// {
+	//     "name": "Tom",
+	//     "age": 31
+	// }
+}
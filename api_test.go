@@ -1,10 +1,15 @@
 package dasel_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tomwright/dasel/v3"
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
 )
 
 type modifyTestCase struct {
@@ -239,4 +244,249 @@ func TestModify(t *testing.T) {
 			count:    1,
 		}.run)
 	})
+
+	t.Run("struct", func(t *testing.T) {
+		type server struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port"`
+		}
+		type config struct {
+			Name    string   `json:"name"`
+			Servers []server `json:"servers"`
+		}
+		newConfig := func() config {
+			return config{
+				Name:    "app",
+				Servers: []server{{Host: "a", Port: 80}, {Host: "b", Port: 80}},
+			}
+		}
+
+		t.Run("field", func(t *testing.T) {
+			cfg := newConfig()
+			count, err := dasel.Modify(t.Context(), &cfg, "servers[1].port", 8080)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("unexpected count: %d", count)
+			}
+			exp := newConfig()
+			exp.Servers[1].Port = 8080
+			if !cmp.Equal(exp, cfg) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exp, cfg))
+			}
+		})
+
+		t.Run("many fields", func(t *testing.T) {
+			cfg := newConfig()
+			count, err := dasel.Modify(t.Context(), &cfg, "servers.map(host)...", "c")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != 2 {
+				t.Errorf("unexpected count: %d", count)
+			}
+			exp := newConfig()
+			exp.Servers[0].Host = "c"
+			exp.Servers[1].Host = "c"
+			if !cmp.Equal(exp, cfg) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exp, cfg))
+			}
+		})
+
+		t.Run("assignment", func(t *testing.T) {
+			cfg := newConfig()
+			if _, _, err := dasel.Query(t.Context(), &cfg, `name = "other"`); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Name != "other" {
+				t.Errorf("unexpected name: %q", cfg.Name)
+			}
+		})
+
+		t.Run("select", func(t *testing.T) {
+			cfg := newConfig()
+			result, _, err := dasel.Select(t.Context(), cfg, "servers.filter(host == \"b\")")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			exp := []any{[]any{map[string]any{"host": "b", "port": int64(80)}}}
+			if !cmp.Equal(exp, result) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exp, result))
+			}
+		})
+
+		t.Run("time field", func(t *testing.T) {
+			type event struct {
+				Name string    `json:"name"`
+				At   time.Time `json:"at"`
+			}
+			e := event{Name: "deploy", At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+			result, _, err := dasel.Select(t.Context(), e, "at")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if exp := []any{"2024-01-02T03:04:05Z"}; !cmp.Equal(exp, result) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exp, result))
+			}
+
+			writer, err := parsing.Format("json").NewWriter(parsing.DefaultWriterOptions())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := writer.Write(model.NewValue(e))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			exp := "{\n    \"name\": \"deploy\",\n    \"at\": \"2024-01-02T03:04:05Z\"\n}\n"
+			if string(out) != exp {
+				t.Errorf("expected %q, got %q", exp, out)
+			}
+
+			if _, err := dasel.Modify(t.Context(), &e, "at", "2025-06-07T08:09:10Z"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if exp := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC); !e.At.Equal(exp) {
+				t.Errorf("unexpected time: %v", e.At)
+			}
+		})
+	})
+}
+
+func TestCompile(t *testing.T) {
+	t.Run("run many times", func(t *testing.T) {
+		program, err := dasel.Compile(`users.map(name)...`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		inputs := []map[string]any{
+			{"users": []map[string]any{{"name": "Alice"}, {"name": "Bob"}}},
+			{"users": []map[string]any{{"name": "Tom"}}},
+		}
+		exps := [][]any{{"Alice", "Bob"}, {"Tom"}}
+		for i, in := range inputs {
+			result, count, err := program.Select(t.Context(), in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != len(exps[i]) {
+				t.Errorf("unexpected count: %d", count)
+			}
+			if !cmp.Equal(exps[i], result) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exps[i], result))
+			}
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		program, err := dasel.Compile(`$greeting + " " + name`, execution.WithVariable("greeting", model.NewStringValue("Hello")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, _, err := program.Select(t.Context(), map[string]any{"name": "Alice"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := []any{"Hello Alice"}
+		if !cmp.Equal(exp, result) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, result))
+		}
+
+		result, _, err = program.Select(t.Context(), map[string]any{"name": "Bob"}, execution.WithVariable("greeting", model.NewStringValue("Hi")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp = []any{"Hi Bob"}
+		if !cmp.Equal(exp, result) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, result))
+		}
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		program, err := dasel.Compile(`$this[1]`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, v := range []int{4, 5} {
+			in := []int{1, 2, 3}
+			count, err := program.Modify(t.Context(), &in, v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("unexpected count: %d", count)
+			}
+			exp := []int{1, v, 3}
+			if !cmp.Equal(exp, in) {
+				t.Errorf("unexpected result: %s", cmp.Diff(exp, in))
+			}
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := dasel.Compile(`true ?`); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
+
+func TestQueryInto(t *testing.T) {
+	type user struct {
+		Name  string   `dasel:"name"`
+		Age   int      `json:"age"`
+		Roles []string `json:"roles,omitempty"`
+	}
+	inputData := map[string]any{
+		"users": []any{
+			map[string]any{"name": "Alice", "age": 30, "roles": []any{"admin"}},
+			map[string]any{"name": "Bob", "age": 25},
+		},
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		var got user
+		if err := dasel.QueryInto(t.Context(), inputData, "users[0]", &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := user{Name: "Alice", Age: 30, Roles: []string{"admin"}}
+		if !cmp.Equal(exp, got) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		var got []user
+		if err := dasel.QueryInto(t.Context(), inputData, "users", &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := []user{{Name: "Alice", Age: 30, Roles: []string{"admin"}}, {Name: "Bob", Age: 25}}
+		if !cmp.Equal(exp, got) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("many results", func(t *testing.T) {
+		var got []string
+		if err := dasel.QueryInto(t.Context(), inputData, "users.filter(age > 27).map(name)...", &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exp := []string{"Alice"}; !cmp.Equal(exp, got) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var got []struct {
+			Name int `json:"name"`
+		}
+		err := dasel.QueryInto(t.Context(), inputData, "users", &got)
+		var decodeErr model.DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("expected DecodeError, got %v", err)
+		}
+		if decodeErr.Path != "[0].name" {
+			t.Errorf("unexpected path: %q", decodeErr.Path)
+		}
+	})
 }
@@ -0,0 +1,270 @@
+package dasel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/internal/atomicfile"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// ErrNoDocumentPath is returned by Document.Save when the document was not opened from a file.
+var ErrNoDocumentPath = errors.New("document has no path")
+
+// Document is a parsed document that can be queried, edited and written back in its original format.
+// Metadata recorded by the reader, such as YAML styles, TOML table styles and XML comments, is kept
+// so that edits round-trip with as few unrelated changes as possible.
+// A Document is not safe for concurrent use.
+//
+// This package does not register any formats itself. Import the format packages you need for their side effects,
+// e.g. _ "github.com/tomwright/dasel/v3/parsing/yaml", before calling Open or Parse.
+type Document struct {
+	root   *model.Value
+	format parsing.Format
+	path   string
+	mode   os.FileMode
+	opts   documentOptions
+}
+
+type documentOptions struct {
+	format        parsing.Format
+	readerOptions parsing.ReaderOptions
+	writerOptions parsing.WriterOptions
+	executeOpts   []execution.ExecuteOptionFn
+}
+
+// DocumentOption configures a Document.
+type DocumentOption func(*documentOptions)
+
+// WithFormat sets the format of a document opened with Open, rather than detecting it.
+func WithFormat(format parsing.Format) DocumentOption {
+	return func(o *documentOptions) {
+		o.format = format
+	}
+}
+
+// WithReaderOptions sets the options used to read the document.
+func WithReaderOptions(opts parsing.ReaderOptions) DocumentOption {
+	return func(o *documentOptions) {
+		o.readerOptions = opts
+	}
+}
+
+// WithWriterOptions sets the options used to write the document.
+func WithWriterOptions(opts parsing.WriterOptions) DocumentOption {
+	return func(o *documentOptions) {
+		o.writerOptions = opts
+	}
+}
+
+// WithExecuteOptions sets options that are applied each time a selector is run against the document.
+func WithExecuteOptions(opts ...execution.ExecuteOptionFn) DocumentOption {
+	return func(o *documentOptions) {
+		o.executeOpts = append(o.executeOpts, opts...)
+	}
+}
+
+func newDocumentOptions(opts []DocumentOption) documentOptions {
+	o := documentOptions{
+		readerOptions: parsing.DefaultReaderOptions(),
+		writerOptions: parsing.DefaultWriterOptions(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Open reads and parses the file at the given path.
+// The format is detected from the file extension or contents unless given with WithFormat.
+// Save writes the document back to the same path, keeping the file's permissions.
+// The format's package must be imported, e.g. _ "github.com/tomwright/dasel/v3/parsing/yaml".
+func Open(path string, opts ...DocumentOption) (*Document, error) {
+	o := newDocumentOptions(opts)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseDocument(data, o.format, path, o)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing file %q: %w", path, err)
+	}
+	doc.path = path
+	doc.mode = info.Mode().Perm()
+	return doc, nil
+}
+
+// Parse parses the data in the given format.
+// If format is empty, it is detected from the data.
+// The format's package must be imported, e.g. _ "github.com/tomwright/dasel/v3/parsing/json".
+func Parse(data []byte, format parsing.Format, opts ...DocumentOption) (*Document, error) {
+	return parseDocument(data, format, "", newDocumentOptions(opts))
+}
+
+func parseDocument(data []byte, format parsing.Format, filename string, o documentOptions) (*Document, error) {
+	if format == "" {
+		detected, err := parsing.DetectFormat(data, filename)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	reader, err := format.NewReader(o.readerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reader: %w", err)
+	}
+	root, err := reader.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		root:   root,
+		format: format,
+		opts:   o,
+	}, nil
+}
+
+// Format returns the format the document was read in, and is written in.
+func (d *Document) Format() parsing.Format {
+	return d.format
+}
+
+// Path returns the path the document was opened from, or an empty string if it was parsed from bytes.
+func (d *Document) Path() string {
+	return d.path
+}
+
+// Root returns the root value of the document.
+// Changes made to the returned value are reflected in the document.
+func (d *Document) Root() *model.Value {
+	return d.root
+}
+
+// execute runs the expression against the document.
+// The root of the document is available to the expression as $root.
+func (d *Document) execute(ctx context.Context, expr ast.Expr, extraOpts ...execution.ExecuteOptionFn) (*model.Value, error) {
+	program, err := execution.CompileAST(expr)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling selector: %w", err)
+	}
+	opts := append(slices.Clone(d.opts.executeOpts), execution.WithVariable("root", d.root))
+	opts = append(opts, extraOpts...)
+	out, err := program.Execute(ctx, d.root, execution.NewOptions(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("error executing selector: %w", err)
+	}
+	return out, nil
+}
+
+// Query runs the selector against the document and returns the results.
+// Changes made to the returned values are reflected in the document.
+func (d *Document) Query(ctx context.Context, selectorStr string) ([]*model.Value, int, error) {
+	expr, err := selector.Parse(selectorStr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error parsing selector: %w", err)
+	}
+	out, err := d.execute(ctx, expr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if out.IsBranch() || out.IsSpread() {
+		res := make([]*model.Value, 0)
+		if err := out.RangeSlice(func(i int, v *model.Value) error {
+			res = append(res, v)
+			return nil
+		}); err != nil {
+			return nil, 0, err
+		}
+		return res, len(res), nil
+	}
+
+	return []*model.Value{out}, 1, nil
+}
+
+// Set assigns the given value to each value matched by the selector and returns the number of values set.
+// It behaves like the = operator: a missing final map key or slice index is created,
+// and a replacement of the same type keeps the original's format metadata, so that e.g. a quoted YAML string stays quoted.
+// A selector that matches nothing sets nothing and returns 0.
+func (d *Document) Set(ctx context.Context, selectorStr string, value any) (int, error) {
+	expr, err := selector.Parse(selectorStr)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing selector: %w", err)
+	}
+	assign := ast.BinaryExpr{
+		Left:     expr,
+		Operator: lexer.Token{Kind: lexer.Equals, Value: "="},
+		Right:    ast.VariableExpr{Name: "value"},
+	}
+	out, err := d.execute(ctx, assign, execution.WithVariable("value", model.NewValue(value)))
+	if err != nil {
+		return 0, err
+	}
+	if out.IsBranch() || out.IsSpread() {
+		return out.SliceLen()
+	}
+	return 1, nil
+}
+
+// Delete removes the values matched by the selector from the document.
+func (d *Document) Delete(ctx context.Context, selectorStr string) error {
+	expr, err := selector.Parse(selectorStr)
+	if err != nil {
+		return fmt.Errorf("error parsing selector: %w", err)
+	}
+	if _, err := d.execute(ctx, ast.DeleteExpr{Exprs: ast.Expressions{expr}}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Bytes returns the document written in its format.
+func (d *Document) Bytes() ([]byte, error) {
+	writer, err := d.format.NewWriter(d.opts.writerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get writer: %w", err)
+	}
+	return writer.Write(d.root)
+}
+
+// Save atomically writes the document back to the file it was opened from, keeping the file's original permissions.
+// ErrNoDocumentPath is returned if the document was not opened from a file.
+func (d *Document) Save() error {
+	if d.path == "" {
+		return ErrNoDocumentPath
+	}
+	return d.SaveAs(d.path)
+}
+
+// SaveAs atomically writes the document to the file at the given path.
+// The file is given the permissions of the file the document was opened from, or 0644 if it was parsed from bytes.
+func (d *Document) SaveAs(path string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+	mode := d.mode
+	if mode == 0 {
+		mode = 0o644
+	}
+	if err := atomicfile.WriteFile(path, data, mode); err != nil {
+		return fmt.Errorf("error writing file %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package dasel_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tomwright/dasel/v3"
+	"github.com/tomwright/dasel/v3/parsing"
+	_ "github.com/tomwright/dasel/v3/parsing/json"
+	_ "github.com/tomwright/dasel/v3/parsing/toml"
+	_ "github.com/tomwright/dasel/v3/parsing/xml"
+	_ "github.com/tomwright/dasel/v3/parsing/yaml"
+)
+
+func TestDocument(t *testing.T) {
+	type edit struct {
+		set    string
+		value  any
+		delete string
+	}
+	testCases := []struct {
+		name   string
+		format parsing.Format
+		in     string
+		edits  []edit
+		exp    string
+	}{
+		{
+			name:   "yaml keeps quote styles",
+			format: "yaml",
+			in: `name: "Tom"
+age: 31
+tags:
+    - 'a'
+    - b
+`,
+			edits: []edit{
+				{set: "name", value: "Jim"},
+				{set: "tags.first()", value: "z"},
+				{delete: "age"},
+			},
+			exp: `name: "Jim"
+tags:
+    - 'z'
+    - b
+`,
+		},
+		{
+			name:   "toml keeps table and string styles",
+			format: "toml",
+			in: `title = 'x'
+
+[server]
+port = 1
+host = "a"
+
+[[items]]
+n = 1
+`,
+			edits: []edit{
+				{set: "title", value: "y"},
+				{set: "server.port", value: int64(2)},
+				{delete: "server.host"},
+			},
+			exp: `title = 'y'
+
+[server]
+port = 2
+
+[[items]]
+n = 1
+`,
+		},
+		{
+			name:   "xml keeps comments",
+			format: "xml",
+			in:     `<root><!-- c --><a>1</a><b>2</b></root>`,
+			edits: []edit{
+				{set: "root.b", value: "3"},
+			},
+			exp: `<root>
+  <!-- c -->
+  <a>1</a>
+  <b>3</b>
+</root>
+`,
+		},
+		{
+			name:   "json adds missing keys",
+			format: "json",
+			in:     `{"a": 1, "list": [1]}`,
+			edits: []edit{
+				{set: "b", value: "x"},
+				{set: "list[1]", value: int64(2)},
+			},
+			exp: `{
+    "a": 1,
+    "list": [
+        1,
+        2
+    ],
+    "b": "x"
+}
+`,
+		},
+		{
+			name:   "format is detected",
+			format: "",
+			in:     `{"a": 1, "b": 2}`,
+			edits: []edit{
+				{delete: "b"},
+			},
+			exp: `{
+    "a": 1
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := dasel.Parse([]byte(tc.in), tc.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, e := range tc.edits {
+				if e.delete != "" {
+					if err := doc.Delete(t.Context(), e.delete); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					continue
+				}
+				if _, err := doc.Set(t.Context(), e.set, e.value); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			got, err := doc.Bytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tc.exp {
+				t.Errorf("expected:\n%s\ngot:\n%s", tc.exp, got)
+			}
+		})
+	}
+
+	t.Run("query", func(t *testing.T) {
+		doc, err := dasel.Parse([]byte(`{"users": [{"name": "Alice"}, {"name": "Bob"}]}`), "json")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res, count, err := doc.Query(t.Context(), `users.map(name)...`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("expected 2 results, got %d", count)
+		}
+		if name, _ := res[1].StringValue(); name != "Bob" {
+			t.Errorf("expected Bob, got %q", name)
+		}
+		if doc.Format() != "json" {
+			t.Errorf("unexpected format: %s", doc.Format())
+		}
+	})
+
+	t.Run("set count", func(t *testing.T) {
+		doc, err := dasel.Parse([]byte(`{"tags": ["a", "b"], "empty": []}`), "json")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count, err := doc.Set(t.Context(), `tags...`, "x")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 values set, got %d", count)
+		}
+		count, err = doc.Set(t.Context(), `empty...`, "x")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("expected 0 values set, got %d", count)
+		}
+	})
+
+	t.Run("open and save", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte("name: 'Tom'\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		doc, err := dasel.Open(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if doc.Format() != "yaml" {
+			t.Errorf("unexpected format: %s", doc.Format())
+		}
+		if _, err := doc.Set(t.Context(), "name", "Jim"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := doc.Save(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := "name: 'Jim'\n"; string(got) != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("save parsed document", func(t *testing.T) {
+		doc, err := dasel.Parse([]byte(`{}`), "json")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := doc.Save(); !errors.Is(err, dasel.ErrNoDocumentPath) {
+			t.Errorf("expected ErrNoDocumentPath, got %v", err)
+		}
+	})
+}
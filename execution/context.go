@@ -11,6 +11,7 @@ const (
 	executorIDCtxKey    ctxKey = "executorID"
 	executorPathCtxKey  ctxKey = "executorPath"
 	executorDepthCtxKey ctxKey = "executorDepth"
+	importStackCtxKey   ctxKey = "importStack"
 )
 
 func WithExecutorID(ctx context.Context, executorID string) context.Context {
@@ -47,3 +48,12 @@ func ExecutorDepth(ctx context.Context) int {
 	}
 	return v
 }
+
+// importStack returns the absolute paths of the selector files currently being imported.
+func importStack(ctx context.Context) []string {
+	v, ok := ctx.Value(importStackCtxKey).([]string)
+	if !ok {
+		return nil
+	}
+	return v
+}
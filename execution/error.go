@@ -0,0 +1,101 @@
+package execution
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// ExecutionError is returned when an expression fails to execute.
+// Errors from nested expressions are wrapped in turn, so the innermost ExecutionError
+// relates to the expression that caused the failure.
+type ExecutionError struct {
+	Expr ast.Expr
+	Err  error
+}
+
+// Error returns the error message.
+func (e ExecutionError) Error() string {
+	return fmt.Sprintf("execution error when processing %T: %s", e.Expr, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Span returns the location of the expression within the selector, if it is known.
+func (e ExecutionError) Span() (lexer.Span, bool) {
+	return ast.SpanOf(e.Expr)
+}
+
+// ModuleError is returned when an error occurs within an imported selector file.
+// Any spans within Err relate to the imported file rather than the executed selector.
+type ModuleError struct {
+	// Path is the absolute path of the imported file.
+	Path string
+	Err  error
+}
+
+// Error returns the error message.
+func (e ModuleError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e ModuleError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnknownFunction is returned when a selector calls a function that does not exist.
+type ErrUnknownFunction struct {
+	Name string
+	// Suggestion is the name of a similarly named function, if there is one.
+	Suggestion string
+}
+
+// Error returns the error message.
+func (e ErrUnknownFunction) Error() string {
+	return fmt.Sprintf("unknown function: %q", e.Name)
+}
+
+// suggestName returns the candidate most similar to name, or an empty string if none are similar enough.
+func suggestName(name string, candidates []string) string {
+	slices.Sort(candidates)
+	maxDistance := min(1+len(name)/4, 3)
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, name) {
+			return candidate
+		}
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
@@ -2,28 +2,20 @@ package execution
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/tomwright/dasel/v3/model"
-	"github.com/tomwright/dasel/v3/selector"
 	"github.com/tomwright/dasel/v3/selector/ast"
 	"os"
-	"reflect"
-	"slices"
 )
 
 // ExecuteSelector parses the selector and executes the resulting AST with the given input.
 func ExecuteSelector(ctx context.Context, selectorStr string, value *model.Value, opts *Options) (*model.Value, error) {
-	if selectorStr == "" {
-		return value, nil
-	}
-
-	expr, err := selector.Parse(selectorStr)
+	program, err := CompileSelector(selectorStr)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing selector: %w", err)
+		return nil, err
 	}
 
-	res, err := ExecuteAST(ctx, expr, value, opts)
+	res, err := program.Execute(ctx, value, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error executing selector: %w", err)
 	}
@@ -35,68 +27,96 @@ type expressionExecutor func(ctx context.Context, options *Options, data *model.
 
 // ExecuteAST executes the given AST with the given input.
 func ExecuteAST(ctx context.Context, expr ast.Expr, value *model.Value, options *Options) (*model.Value, error) {
+	program, err := CompileAST(expr)
+	if err != nil {
+		return nil, err
+	}
+	return program.Execute(ctx, value, options)
+}
+
+// compileAST builds the executor for the given AST.
+// The executors of all child expressions are built up front, so the result can be executed many times.
+func compileAST(expr ast.Expr) (expressionExecutor, error) {
 	if expr == nil {
-		return value, nil
+		return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+			return data, nil
+		}, nil
 	}
 
-	executorFn, err := exprExecutor(options, expr)
+	executor, err := exprExecutor(expr)
 	if err != nil {
 		return nil, fmt.Errorf("error evaluating expression %T: %w", expr, err)
 	}
 
-	executor := func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
-		options.Vars["this"] = data
-		out, err := executorFn(ctx, options, data)
-		if err != nil {
-			return out, err
+	return func(ctx context.Context, options *Options, value *model.Value) (*model.Value, error) {
+		if err := options.enter(ctx); err != nil {
+			return nil, err
 		}
-		return out, nil
-	}
+		defer options.leave()
 
-	if !value.IsBranch() {
-		res, err := executor(ctx, options, value)
-		if err != nil {
-			return nil, fmt.Errorf("execution error when processing %T: %w", expr, err)
+		executor := executor
+		if options.Tracer != nil {
+			traced := executor
+			executor = func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+				return traceExecutor(ctx, options, expr, traced, data)
+			}
 		}
-		return res, nil
-	}
-
-	res := model.NewSliceValue()
-	res.MarkAsBranch()
 
-	if err := value.RangeSlice(func(i int, v *model.Value) error {
-		r, err := executor(ctx, options, v)
-		if err != nil {
-			return err
-		}
-		if r.IsIgnore() {
-			return nil
+		if !value.IsBranch() {
+			res, err := executor(ctx, options, value)
+			if err != nil {
+				if depthErr, ok := maxDepthError(err); ok {
+					return nil, depthErr
+				}
+				return nil, ExecutionError{Expr: expr, Err: err}
+			}
+			return res, nil
 		}
-		return res.Append(r)
-	}); err != nil {
-		return nil, fmt.Errorf("branch execution error when processing %T: %w", expr, err)
-	}
 
-	return res, nil
-}
+		res := model.NewSliceValue()
+		res.MarkAsBranch()
 
-var unstableAstTypes = []reflect.Type{
-	reflect.TypeFor[ast.BranchExpr](),
+		if err := value.RangeSlice(func(i int, v *model.Value) error {
+			r, err := executor(ctx, options, v)
+			if err != nil {
+				return err
+			}
+			if r.IsIgnore() {
+				return nil
+			}
+			return res.Append(r)
+		}); err != nil {
+			if depthErr, ok := maxDepthError(err); ok {
+				return nil, depthErr
+			}
+			return nil, fmt.Errorf("branch execution error when processing %T: %w", expr, err)
+		}
+
+		return res, nil
+	}, nil
 }
 
-func exprExecutor(options *Options, expr ast.Expr) (expressionExecutor, error) {
-	if !options.Unstable && (slices.Contains(unstableAstTypes, reflect.TypeOf(expr)) ||
-		slices.Contains(unstableAstTypes, reflect.ValueOf(expr).Type())) {
-		return nil, errors.New("unstable ast types are not enabled. to enable them use --unstable")
+// compileASTs builds the executors for the given expressions.
+func compileASTs(exprs ast.Expressions) ([]expressionExecutor, error) {
+	res := make([]expressionExecutor, len(exprs))
+	for i, expr := range exprs {
+		executor, err := compileAST(expr)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = executor
 	}
+	return res, nil
+}
 
+func exprExecutor(expr ast.Expr) (expressionExecutor, error) {
 	switch e := expr.(type) {
 	case ast.BinaryExpr:
 		return binaryExprExecutor(e)
 	case ast.UnaryExpr:
 		return unaryExprExecutor(e)
 	case ast.CallExpr:
-		return callExprExecutor(options, e)
+		return callExprExecutor(e)
 	case ast.ChainedExpr:
 		return chainedExprExecutor(e)
 	case ast.SpreadExpr:
@@ -115,6 +135,8 @@ func exprExecutor(options *Options, expr ast.Expr) (expressionExecutor, error) {
 		return numberFloatExprExecutor(e)
 	case ast.StringExpr:
 		return stringExprExecutor(e)
+	case ast.InterpolatedStringExpr:
+		return interpolatedStringExprExecutor(e)
 	case ast.BoolExpr:
 		return boolExprExecutor(e)
 	case ast.ObjectExpr:
@@ -136,10 +158,9 @@ func exprExecutor(options *Options, expr ast.Expr) (expressionExecutor, error) {
 	case ast.ArrayExpr:
 		return arrayExprExecutor(e)
 	case ast.RegexExpr:
-		// Noop
 		return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 			//ctx = WithExecutorID(ctx, "regexExpr")
-			return data, nil
+			return regexValue(e.Regex), nil
 		}, nil
 	case ast.SortByExpr:
 		return sortByExprExecutor(e)
@@ -153,6 +174,14 @@ func exprExecutor(options *Options, expr ast.Expr) (expressionExecutor, error) {
 		return anyExprExecutor(e)
 	case ast.AllExpr:
 		return allExprExecutor(e)
+	case ast.FuncDefExpr:
+		return funcDefExprExecutor(e)
+	case ast.ImportExpr:
+		return importExprExecutor(e)
+	case ast.DeleteExpr:
+		return deleteExprExecutor(e)
+	case ast.WalkExpr:
+		return walkExprExecutor(e)
 	case ast.CountExpr:
 		return countExprExecutor(e)
 	case ast.NullExpr:
@@ -166,11 +195,15 @@ func exprExecutor(options *Options, expr ast.Expr) (expressionExecutor, error) {
 }
 
 func chainedExprExecutor(e ast.ChainedExpr) (expressionExecutor, error) {
+	exprs, err := compileASTs(e.Exprs)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "chainedExpr")
 		var curData = data
-		for _, expr := range e.Exprs {
-			res, err := ExecuteAST(ctx, expr, curData, options)
+		for _, expr := range exprs {
+			res, err := expr(ctx, options, curData)
 			if err != nil {
 				return nil, fmt.Errorf("error executing expression: %w", err)
 			}
@@ -184,11 +217,18 @@ func variableExprExecutor(e ast.VariableExpr) (expressionExecutor, error) {
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		//ctx = WithExecutorID(ctx, "variableExpr")
 		varName := e.Name
-		res, ok := options.Vars[varName]
+		if varName == "this" {
+			return data, nil
+		}
+		res, ok := options.scope.lookupVar(varName)
 		if ok {
 			return res, nil
 		}
 
+		if varName == "path" {
+			return pathValue(valuePath(options, data))
+		}
+
 		envVarValue := os.Getenv(varName)
 		if envVarValue != "" {
 			return model.NewStringValue(envVarValue), nil
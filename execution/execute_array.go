@@ -9,12 +9,16 @@ import (
 )
 
 func arrayExprExecutor(e ast.ArrayExpr) (expressionExecutor, error) {
+	exprs, err := compileASTs(e.Exprs)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "arrayExpr")
 		res := model.NewSliceValue()
 
-		for _, expr := range e.Exprs {
-			el, err := ExecuteAST(ctx, expr, data, options)
+		for _, expr := range exprs {
+			el, err := expr(ctx, options, data)
 			if err != nil {
 				return nil, err
 			}
@@ -40,11 +44,19 @@ func arrayExprExecutor(e ast.ArrayExpr) (expressionExecutor, error) {
 }
 
 func rangeExprExecutor(e ast.RangeExpr) (expressionExecutor, error) {
+	startExec, err := compileAST(e.Start)
+	if err != nil {
+		return nil, err
+	}
+	endExec, err := compileAST(e.End)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "rangeExpr")
 		var start, end int64 = 0, -1
 		if e.Start != nil {
-			startE, err := ExecuteAST(ctx, e.Start, data, options)
+			startE, err := startExec(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error evaluating start expression: %w", err)
 			}
@@ -56,7 +68,7 @@ func rangeExprExecutor(e ast.RangeExpr) (expressionExecutor, error) {
 		}
 
 		if e.End != nil {
-			endE, err := ExecuteAST(ctx, e.End, data, options)
+			endE, err := endExec(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error evaluating end expression: %w", err)
 			}
@@ -88,9 +100,13 @@ func rangeExprExecutor(e ast.RangeExpr) (expressionExecutor, error) {
 }
 
 func indexExprExecutor(e ast.IndexExpr) (expressionExecutor, error) {
+	indexExec, err := compileAST(e.Index)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "indexExpr")
-		indexE, err := ExecuteAST(ctx, e.Index, data, options)
+		indexE, err := indexExec(ctx, options, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating index expression: %w", err)
 		}
@@ -100,6 +116,10 @@ func indexExprExecutor(e ast.IndexExpr) (expressionExecutor, error) {
 			return nil, fmt.Errorf("error getting index int value: %w", err)
 		}
 
-		return data.GetSliceIndex(int(index))
+		res, err := data.GetSliceIndex(int(index))
+		if err != nil {
+			return nil, err
+		}
+		return trackPath(options, data, res, int(index)), nil
 	}, nil
 }
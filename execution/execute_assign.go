@@ -2,15 +2,134 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/selector/ast"
 )
 
+// executeAssign replaces left with right.
+// If right has no metadata of its own and is the same type as left, left's format metadata is kept,
+// so that e.g. a quoted YAML string stays quoted. right is copied first, since it may be assigned elsewhere too.
 func executeAssign(ctx context.Context, left *model.Value, right *model.Value, _ ast.BinaryExpr) (*model.Value, error) {
+	if len(right.Metadata) == 0 && len(left.Metadata) > 0 && right.Type() == left.Type() {
+		var err error
+		right, err = copyValue(right)
+		if err != nil {
+			return nil, fmt.Errorf("error copying value: %w", err)
+		}
+		right.Metadata = maps.Clone(left.Metadata)
+	}
 	err := left.Set(right)
 	if err != nil {
 		return nil, fmt.Errorf("error setting value: %w", err)
 	}
 	return right, nil
 }
+
+// assignMissingExecutor returns an executor that assigns right to the final map key or slice index of left,
+// creating it if it does not exist. An index equal to the length of the slice appends to it.
+// It returns nil if left does not end in a property or index.
+func assignMissingExecutor(left ast.Expr, right expressionExecutor) (expressionExecutor, error) {
+	if group, ok := left.(ast.GroupExpr); ok {
+		return assignMissingExecutor(group.Expr, right)
+	}
+
+	exprs := flattenChain(left)
+	var keyE ast.Expr
+	switch last := exprs[len(exprs)-1].(type) {
+	case ast.PropertyExpr:
+		keyE = last.Property
+	case ast.IndexExpr:
+		keyE = last.Index
+	default:
+		return nil, nil
+	}
+	key, err := compileAST(keyE)
+	if err != nil {
+		return nil, err
+	}
+	var prefix expressionExecutor
+	if prefixE := ast.ChainExprs(exprs[:len(exprs)-1]...); prefixE != nil {
+		prefix, err = compileAST(prefixE)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		parents := model.Values{data}
+		branched := false
+		if prefix != nil {
+			res, err := prefix(ctx, options, data)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating left expression: %w", err)
+			}
+			parents = model.Values{res}
+			if res.IsBranch() || res.IsSpread() {
+				branched = true
+				parents = nil
+				if err := res.RangeSlice(func(_ int, v *model.Value) error {
+					parents = append(parents, v)
+					return nil
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		assigned := make(model.Values, 0, len(parents))
+		for _, parent := range parents {
+			k, err := key(ctx, options, parent)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating left expression: %w", err)
+			}
+			var segment any
+			switch {
+			case k.IsString():
+				segment, err = k.StringValue()
+			case k.IsInt():
+				var i int64
+				i, err = k.IntValue()
+				segment = int(i)
+			default:
+				return nil, fmt.Errorf("cannot assign to key of type %s", k.Type())
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := right(ctx, options, data)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating right expression: %w", err)
+			}
+			if existing, err := getPathSegment(parent, segment); err == nil {
+				if _, err := executeAssign(ctx, existing, r, ast.BinaryExpr{}); err != nil {
+					return nil, err
+				}
+			} else if err := setPathSegment(parent, segment, r); err != nil {
+				return nil, fmt.Errorf("error setting value: %w", err)
+			}
+			assigned = append(assigned, r)
+		}
+
+		if !branched {
+			return assigned[0], nil
+		}
+		res := model.NewSliceValue()
+		res.MarkAsBranch()
+		for _, v := range assigned {
+			if err := res.Append(v); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}, nil
+}
+
+// isMissingKeyErr reports whether err was caused by a map key or slice index that does not exist.
+func isMissingKeyErr(err error) bool {
+	return errors.As(err, &model.MapKeyNotFound{}) || errors.As(err, &model.SliceIndexOutOfRange{})
+}
@@ -10,52 +10,81 @@ import (
 	"github.com/tomwright/dasel/v3/selector/lexer"
 )
 
-type binaryExpressionExecutorFn func(ctx context.Context, expr ast.BinaryExpr, value *model.Value, options *Options) (*model.Value, error)
+// binaryExpr is a binary expression with its left and right executors already built.
+type binaryExpr struct {
+	ast.BinaryExpr
+	left  expressionExecutor
+	right expressionExecutor
+	// assignMissing creates the final key or index of the left side of an assignment when it does not exist.
+	assignMissing expressionExecutor
+}
+
+type binaryExpressionExecutorFn func(ctx context.Context, expr binaryExpr, value *model.Value, options *Options) (*model.Value, error)
 
 func basicBinaryExpressionExecutorFn(handler func(ctx context.Context, left *model.Value, right *model.Value, e ast.BinaryExpr) (*model.Value, error)) binaryExpressionExecutorFn {
-	return func(ctx context.Context, expr ast.BinaryExpr, value *model.Value, options *Options) (*model.Value, error) {
-		left, err := ExecuteAST(ctx, expr.Left, value, options)
+	return func(ctx context.Context, expr binaryExpr, value *model.Value, options *Options) (*model.Value, error) {
+		left, err := expr.left(ctx, options, value)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating left expression: %w", err)
 		}
+		return executeBinaryHandler(ctx, expr, left, left.IsBranch(), value, options, handler)
+	}
+}
 
-		if !left.IsBranch() {
-			right, err := ExecuteAST(ctx, expr.Right, value, options)
-			if err != nil {
-				return nil, fmt.Errorf("error evaluating right expression: %w", err)
-			}
-			res, err := handler(ctx, left, right, expr)
-			if err != nil {
-				return nil, err
-			}
-			return res, nil
+// executeBinaryHandler calls handler with the evaluated left side and the right side.
+// If ranged is true, the handler is called for each value in left, with the right side evaluated against that value.
+func executeBinaryHandler(ctx context.Context, expr binaryExpr, left *model.Value, ranged bool, value *model.Value, options *Options, handler func(ctx context.Context, left *model.Value, right *model.Value, e ast.BinaryExpr) (*model.Value, error)) (*model.Value, error) {
+	if !ranged {
+		right, err := expr.right(ctx, options, value)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating right expression: %w", err)
 		}
-
-		res := model.NewSliceValue()
-		res.MarkAsBranch()
-		if err := left.RangeSlice(func(i int, v *model.Value) error {
-			right, err := ExecuteAST(ctx, expr.Right, v, options)
-			if err != nil {
-				return fmt.Errorf("error evaluating right expression: %w", err)
-			}
-			r, err := handler(ctx, v, right, expr)
-			if err != nil {
-				return err
-			}
-			if err := res.Append(r); err != nil {
-				return err
-			}
-			return nil
-		}); err != nil {
+		res, err := handler(ctx, left, right, expr.BinaryExpr)
+		if err != nil {
 			return nil, err
 		}
 		return res, nil
 	}
+
+	res := model.NewSliceValue()
+	res.MarkAsBranch()
+	if err := left.RangeSlice(func(i int, v *model.Value) error {
+		right, err := expr.right(ctx, options, v)
+		if err != nil {
+			return fmt.Errorf("error evaluating right expression: %w", err)
+		}
+		r, err := handler(ctx, v, right, expr.BinaryExpr)
+		if err != nil {
+			return err
+		}
+		if err := res.Append(r); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 var binaryExpressionExecutors = map[lexer.TokenKind]binaryExpressionExecutorFn{}
 
 func binaryExprExecutor(e ast.BinaryExpr) (expressionExecutor, error) {
+	left, err := compileAST(e.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := compileAST(e.Right)
+	if err != nil {
+		return nil, err
+	}
+	expr := binaryExpr{BinaryExpr: e, left: left, right: right}
+	if e.Operator.Kind == lexer.Equals {
+		expr.assignMissing, err = assignMissingExecutor(e.Left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "binaryExpr")
 		if e.Left == nil || e.Right == nil {
@@ -67,7 +96,7 @@ func binaryExprExecutor(e ast.BinaryExpr) (expressionExecutor, error) {
 			return nil, fmt.Errorf("unhandled operator: %s", e.Operator.Value)
 		}
 
-		return exec(ctx, e, data, options)
+		return exec(ctx, expr, data, options)
 	}, nil
 }
 
@@ -105,14 +134,22 @@ func init() {
 	binaryExpressionExecutors[lexer.NotEqual] = basicBinaryExpressionExecutorFn(func(ctx context.Context, left *model.Value, right *model.Value, _ ast.BinaryExpr) (*model.Value, error) {
 		return left.NotEqual(right)
 	})
-	binaryExpressionExecutors[lexer.Equals] = func(ctx context.Context, expr ast.BinaryExpr, value *model.Value, options *Options) (*model.Value, error) {
+	binaryExpressionExecutors[lexer.Equals] = func(ctx context.Context, expr binaryExpr, value *model.Value, options *Options) (*model.Value, error) {
 		if leftVar, ok := expr.Left.(ast.VariableExpr); ok {
 			// It is expected that the left side of an assignment may not exist yet.
-			if _, ok := options.Vars[leftVar.Name]; !ok {
-				options.Vars[leftVar.Name] = model.NewNullValue()
+			if _, ok := options.scope.lookupVar(leftVar.Name); !ok {
+				options.scope.setVar(leftVar.Name, model.NewNullValue())
 			}
 		}
-		return basicBinaryExpressionExecutorFn(executeAssign)(ctx, expr, value, options)
+		left, err := expr.left(ctx, options, value)
+		if err != nil {
+			if expr.assignMissing != nil && isMissingKeyErr(err) {
+				return expr.assignMissing(ctx, options, value)
+			}
+			return nil, fmt.Errorf("error evaluating left expression: %w", err)
+		}
+		// Assigning to a spread assigns to each of the spread values.
+		return executeBinaryHandler(ctx, expr, left, left.IsBranch() || left.IsSpread(), value, options, executeAssign)
 	}
 	binaryExpressionExecutors[lexer.And] = basicBinaryExpressionExecutorFn(func(ctx context.Context, left *model.Value, right *model.Value, _ ast.BinaryExpr) (*model.Value, error) {
 		leftBool, err := left.BoolValue()
@@ -160,8 +197,8 @@ func init() {
 		res := rightPatt.Regex.MatchString(leftStr)
 		return model.NewBoolValue(!res), nil
 	})
-	binaryExpressionExecutors[lexer.DoubleQuestionMark] = func(ctx context.Context, expr ast.BinaryExpr, value *model.Value, options *Options) (*model.Value, error) {
-		left, err := ExecuteAST(ctx, expr.Left, value, options)
+	binaryExpressionExecutors[lexer.DoubleQuestionMark] = func(ctx context.Context, expr binaryExpr, value *model.Value, options *Options) (*model.Value, error) {
+		left, err := expr.left(ctx, options, value)
 
 		if err == nil && !left.IsNull() {
 			return left, nil
@@ -188,7 +225,7 @@ func init() {
 		}
 
 		// Do we need to handle branches here?
-		right, err := ExecuteAST(ctx, expr.Right, value, options)
+		right, err := expr.right(ctx, options, value)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating right expression: %w", err)
 		}
@@ -9,12 +9,16 @@ import (
 )
 
 func branchExprExecutor(e ast.BranchExpr) (expressionExecutor, error) {
+	exprs, err := compileASTs(e.Exprs)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "branchExpr")
 		res := model.NewSliceValue()
 		res.MarkAsBranch()
 
-		if len(e.Exprs) == 0 {
+		if len(exprs) == 0 {
 			// No expressions given. We'll branch on the input data.
 			if err := data.RangeSlice(func(_ int, value *model.Value) error {
 				if err := res.Append(value); err != nil {
@@ -25,8 +29,8 @@ func branchExprExecutor(e ast.BranchExpr) (expressionExecutor, error) {
 				return nil, fmt.Errorf("failed to range slice: %w", err)
 			}
 		} else {
-			for _, expr := range e.Exprs {
-				r, err := ExecuteAST(ctx, expr, data, options)
+			for _, expr := range exprs {
+				r, err := expr(ctx, options, data)
 				if err != nil {
 					return nil, fmt.Errorf("failed to execute branch expr: %w", err)
 				}
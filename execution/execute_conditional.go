@@ -9,9 +9,21 @@ import (
 )
 
 func conditionalExprExecutor(e ast.ConditionalExpr) (expressionExecutor, error) {
+	condExec, err := compileAST(e.Cond)
+	if err != nil {
+		return nil, err
+	}
+	thenExec, err := compileAST(e.Then)
+	if err != nil {
+		return nil, err
+	}
+	elseExec, err := compileAST(e.Else)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "conditionalExpr")
-		cond, err := ExecuteAST(ctx, e.Cond, data, options)
+		cond, err := condExec(ctx, options, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating condition: %w", err)
 		}
@@ -22,7 +34,7 @@ func conditionalExprExecutor(e ast.ConditionalExpr) (expressionExecutor, error)
 		}
 
 		if condBool {
-			res, err := ExecuteAST(ctx, e.Then, data, options)
+			res, err := thenExec(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error executing then block: %w", err)
 			}
@@ -30,7 +42,7 @@ func conditionalExprExecutor(e ast.ConditionalExpr) (expressionExecutor, error)
 		}
 
 		if e.Else != nil {
-			res, err := ExecuteAST(ctx, e.Else, data, options)
+			res, err := elseExec(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error executing else block: %w", err)
 			}
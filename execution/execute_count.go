@@ -9,6 +9,10 @@ import (
 )
 
 func countExprExecutor(e ast.CountExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "countExpr")
 		if !data.IsSlice() {
@@ -17,10 +21,9 @@ func countExprExecutor(e ast.CountExpr) (expressionExecutor, error) {
 
 		var count int64
 		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
+			itemOptions := withKeyVar(options, model.NewIntValue(int64(i)))
 
-			v, err := ExecuteAST(ctx, e.Expr, item, options)
+			v, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
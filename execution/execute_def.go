@@ -0,0 +1,66 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// userFunc is a function defined within a selector.
+type userFunc struct {
+	def  ast.FuncDefExpr
+	body expressionExecutor
+	// scope is the scope the function was defined in.
+	// The body is executed in a child of this scope, so that it can reference the
+	// definitions and variables visible where it was defined, including those of
+	// the file it was imported from.
+	scope *scope
+	// module is the path of the file the function was imported from, if any.
+	module string
+}
+
+func funcDefExprExecutor(e ast.FuncDefExpr) (expressionExecutor, error) {
+	body, err := compileAST(e.Body)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "funcDefExpr")
+		options.scope.setDef(e.Name, userFunc{def: e, body: body, scope: options.scope})
+		return data, nil
+	}, nil
+}
+
+func callDefExecutor(fn userFunc, argsE []expressionExecutor) expressionExecutor {
+	def := fn.def
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "callDefExpr")
+
+		args, err := prepareArgs(ctx, options, data, argsE)
+		if err != nil {
+			return nil, fmt.Errorf("error preparing arguments: %w", err)
+		}
+		if len(args) != len(def.Params) {
+			return nil, fmt.Errorf("func %q expects exactly %d arguments, got %d", def.Name, len(def.Params), len(args))
+		}
+
+		// Parameters are scoped to the function body.
+		params := make(map[string]*model.Value, len(def.Params))
+		for i, param := range def.Params {
+			params[param] = args[i]
+		}
+		bodyOptions := options.withScope(fn.scope, params)
+
+		res, err := fn.body(ctx, bodyOptions, data)
+		if err != nil {
+			err = fmt.Errorf("error executing function: %w", err)
+			if fn.module != "" {
+				return nil, ModuleError{Path: fn.module, Err: err}
+			}
+			return nil, err
+		}
+		return res, nil
+	}
+}
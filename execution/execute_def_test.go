@@ -0,0 +1,59 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncDef(t *testing.T) {
+	t.Run("single param", testCase{
+		s:   `def slug(x) = $x.toLower().replace(" ", "-"); slug("Hello World")`,
+		out: model.NewStringValue("hello-world"),
+	}.run)
+	t.Run("multiple params", testCase{
+		s:   `def add(a, b) = $a + $b; add(1, 2)`,
+		out: model.NewIntValue(3),
+	}.run)
+	t.Run("uses data", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("name", "Tom")),
+		s:   `def greet() = "Hello " + name; greet()`,
+		out: model.NewStringValue("Hello Tom"),
+	}.run)
+	t.Run("used in map", testCase{
+		s:   `def double(x) = $x * 2; [1, 2, 3].map(double($this))`,
+		out: model.NewValue([]any{int64(2), int64(4), int64(6)}),
+	}.run)
+	t.Run("recursion", testCase{
+		s:   `def fact(n) = if ($n <= 1) { 1 } else { $n * fact(($n - 1)) }; fact(5)`,
+		out: model.NewIntValue(120),
+	}.run)
+	t.Run("shadows builtin", testCase{
+		s:   `def len(x) = 42; len("abc")`,
+		out: model.NewIntValue(42),
+	}.run)
+	t.Run("params do not leak", testCase{
+		s:   `$x = 1; def f(x) = $x; f(2); $x`,
+		out: model.NewIntValue(1),
+	}.run)
+
+	t.Run("wrong number of args", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `def f(a) = $a; f(1, 2)`, model.NewNullValue(), execution.NewOptions())
+		if err == nil || !strings.Contains(err.Error(), `func "f" expects exactly 1 arguments, got 2`) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unbounded recursion", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `def f(x) = f($x); f(1)`, model.NewNullValue(), execution.NewOptions(execution.WithMaxDepth(50)))
+		var target execution.ErrMaxDepthExceeded
+		if !errors.As(err, &target) || target.Max != 50 {
+			t.Errorf("expected ErrMaxDepthExceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,349 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// deleteLocation is a map key or slice index that should be deleted from its parent.
+type deleteLocation struct {
+	parent *model.Value
+	key    string
+	index  int
+}
+
+func (l deleteLocation) delete() error {
+	if l.parent.IsMap() {
+		return l.parent.DeleteMapKey(l.key)
+	}
+	return l.parent.DeleteSliceIndex(l.index)
+}
+
+// deleteLocator returns the locations of the values to be deleted from data.
+type deleteLocator func(ctx context.Context, options *Options, data *model.Value) ([]deleteLocation, error)
+
+func deleteExprExecutor(e ast.DeleteExpr) (expressionExecutor, error) {
+	locators := make([]deleteLocator, len(e.Exprs))
+	for i, expr := range e.Exprs {
+		locator, err := deleteLocations(expr)
+		if err != nil {
+			return nil, err
+		}
+		locators[i] = locator
+	}
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "deleteExpr")
+		// Every location is found before anything is deleted, so that each argument
+		// is resolved against the original data.
+		var locations []deleteLocation
+		for _, locator := range locators {
+			found, err := locator(ctx, options, data)
+			if err != nil {
+				return nil, err
+			}
+			locations = append(locations, found...)
+		}
+		if err := deleteAll(locations); err != nil {
+			return nil, fmt.Errorf("error deleting value: %w", err)
+		}
+		return data, nil
+	}, nil
+}
+
+// deleteAll deletes the locations in reverse order, so that nested values are deleted before the values containing them.
+// Indexes within the same slice are deleted together from the highest to the lowest so that they remain valid,
+// and duplicate locations are deleted once.
+func deleteAll(locations []deleteLocation) error {
+	// Slices are identified before anything is deleted, since deleting changes their length.
+	ids := make([]sliceID, len(locations))
+	for i, l := range locations {
+		if !l.parent.IsMap() {
+			ids[i] = sliceIdentity(l.parent)
+		}
+	}
+
+	done := map[sliceID]bool{}
+	for i := len(locations) - 1; i >= 0; i-- {
+		l := locations[i]
+		if l.parent.IsMap() {
+			if err := l.delete(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if done[ids[i]] {
+			continue
+		}
+		done[ids[i]] = true
+
+		var indexes []int
+		for j, other := range locations {
+			if ids[j] == ids[i] && !other.parent.IsMap() && !slices.Contains(indexes, other.index) {
+				indexes = append(indexes, other.index)
+			}
+		}
+		slices.Sort(indexes)
+		for j := len(indexes) - 1; j >= 0; j-- {
+			if err := l.parent.DeleteSliceIndex(indexes[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sliceID identifies the slice underlying a value, since the same slice may be reached through different values.
+type sliceID struct {
+	ptr uintptr
+	len int
+}
+
+func sliceIdentity(v *model.Value) sliceID {
+	rv := reflect.ValueOf(v.UnpackKinds(reflect.Interface, reflect.Pointer).Interface())
+	if rv.Kind() != reflect.Slice {
+		return sliceID{}
+	}
+	return sliceID{ptr: rv.Pointer(), len: rv.Len()}
+}
+
+// deleteLocations returns a locator for the values selected by expr.
+// The final expression in the chain decides which children of the preceding values are selected.
+func deleteLocations(expr ast.Expr) (deleteLocator, error) {
+	if group, ok := expr.(ast.GroupExpr); ok {
+		return deleteLocations(group.Expr)
+	}
+
+	exprs := flattenChain(expr)
+	last := exprs[len(exprs)-1]
+
+	var prefix expressionExecutor
+	if prefixE := ast.ChainExprs(exprs[:len(exprs)-1]...); prefixE != nil {
+		var err error
+		prefix, err = compileAST(prefixE)
+		if err != nil {
+			return nil, err
+		}
+	}
+	children, err := deleteChildLocations(last)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, options *Options, data *model.Value) ([]deleteLocation, error) {
+		parents := model.Values{data}
+		if prefix != nil {
+			res, err := prefix(ctx, options, data)
+			if err != nil {
+				return nil, fmt.Errorf("error resolving delete path: %w", err)
+			}
+			parents = model.Values{res}
+			if res.IsBranch() || res.IsSpread() {
+				parents = nil
+				if err := res.RangeSlice(func(_ int, v *model.Value) error {
+					parents = append(parents, v)
+					return nil
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		var locations []deleteLocation
+		for _, parent := range parents {
+			found, err := children(ctx, options, parent)
+			if err != nil {
+				return nil, err
+			}
+			locations = append(locations, found...)
+		}
+		return locations, nil
+	}, nil
+}
+
+// flattenChain returns the expressions in a chain, expanding any nested chains.
+func flattenChain(expr ast.Expr) ast.Expressions {
+	chain, ok := expr.(ast.ChainedExpr)
+	if !ok {
+		return ast.Expressions{expr}
+	}
+	var res ast.Expressions
+	for _, e := range chain.Exprs {
+		res = append(res, flattenChain(e)...)
+	}
+	return res
+}
+
+// deleteChildLocations returns a locator for the children of a parent that are selected by expr.
+// Missing map keys and slice indexes are ignored. Negative indexes count from the end of the slice.
+func deleteChildLocations(expr ast.Expr) (deleteLocator, error) {
+	switch e := expr.(type) {
+	case ast.PropertyExpr:
+		property, err := compileAST(e.Property)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, options *Options, parent *model.Value) ([]deleteLocation, error) {
+			key, err := property(ctx, options, parent)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating property: %w", err)
+			}
+			return deleteKeyLocation(parent, key)
+		}, nil
+
+	case ast.IndexExpr:
+		indexE, err := compileAST(e.Index)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, options *Options, parent *model.Value) ([]deleteLocation, error) {
+			index, err := indexE(ctx, options, parent)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating index expression: %w", err)
+			}
+			return deleteKeyLocation(parent, index)
+		}, nil
+
+	case ast.SpreadExpr:
+		return func(ctx context.Context, options *Options, parent *model.Value) ([]deleteLocation, error) {
+			var locations []deleteLocation
+			switch {
+			case parent.IsMap():
+				keys, err := parent.MapKeys()
+				if err != nil {
+					return nil, err
+				}
+				for _, key := range keys {
+					locations = append(locations, deleteLocation{parent: parent, key: key})
+				}
+			case parent.IsSlice():
+				l, err := parent.SliceLen()
+				if err != nil {
+					return nil, err
+				}
+				for i := 0; i < l; i++ {
+					locations = append(locations, deleteLocation{parent: parent, index: i})
+				}
+			default:
+				return nil, fmt.Errorf("cannot spread on type %s", parent.Type())
+			}
+			return locations, nil
+		}, nil
+
+	case ast.FilterExpr:
+		filter, err := compileAST(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, options *Options, parent *model.Value) ([]deleteLocation, error) {
+			if !parent.IsSlice() {
+				return nil, fmt.Errorf("cannot filter over non-array")
+			}
+			var locations []deleteLocation
+			if err := parent.RangeSlice(func(i int, item *model.Value) error {
+				itemOptions := withKeyVar(options, model.NewIntValue(int64(i)))
+
+				v, err := filter(ctx, itemOptions, item)
+				if err != nil {
+					return err
+				}
+				match, err := v.BoolValue()
+				if err != nil {
+					return err
+				}
+				if match {
+					locations = append(locations, deleteLocation{parent: parent, index: i})
+				}
+				return nil
+			}); err != nil {
+				return nil, fmt.Errorf("error ranging over slice: %w", err)
+			}
+			return locations, nil
+		}, nil
+
+	case ast.SearchExpr:
+		searchE, err := compileAST(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, options *Options, parent *model.Value) ([]deleteLocation, error) {
+			var locations []deleteLocation
+			var search func(data *model.Value) error
+			search = func(data *model.Value) error {
+				visit := func(key *model.Value, loc deleteLocation, v *model.Value) error {
+					match, err := searchMatches(ctx, searchE, v, withKeyVar(options, key))
+					if err != nil {
+						return err
+					}
+					if match {
+						locations = append(locations, loc)
+					}
+					return search(v)
+				}
+				switch data.Type() {
+				case model.TypeMap:
+					return data.RangeMap(func(key string, v *model.Value) error {
+						return visit(model.NewStringValue(key), deleteLocation{parent: data, key: key}, v)
+					})
+				case model.TypeSlice:
+					return data.RangeSlice(func(i int, v *model.Value) error {
+						return visit(model.NewIntValue(int64(i)), deleteLocation{parent: data, index: i}, v)
+					})
+				}
+				return nil
+			}
+			if err := search(parent); err != nil {
+				return nil, err
+			}
+			return locations, nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("cannot delete %T: expected a property, index, spread, filter or search", expr)
+	}
+}
+
+// deleteKeyLocation returns the location of the given map key or slice index within parent.
+func deleteKeyLocation(parent *model.Value, key *model.Value) ([]deleteLocation, error) {
+	switch {
+	case key.IsString():
+		k, err := key.StringValue()
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsMap() {
+			return nil, fmt.Errorf("cannot delete key %q from %s", k, parent.Type())
+		}
+		exists, err := parent.MapKeyExists(k)
+		if err != nil || !exists {
+			return nil, err
+		}
+		return []deleteLocation{{parent: parent, key: k}}, nil
+	case key.IsInt():
+		i, err := key.IntValue()
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsSlice() {
+			return nil, fmt.Errorf("cannot delete index %d from %s", i, parent.Type())
+		}
+		l, err := parent.SliceLen()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 {
+			i += int64(l)
+		}
+		if i < 0 || int(i) >= l {
+			return nil, nil
+		}
+		return []deleteLocation{{parent: parent, index: int(i)}}, nil
+	default:
+		return nil, fmt.Errorf("expected key to be a string or int, got %s", key.Type())
+	}
+}
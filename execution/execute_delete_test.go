@@ -0,0 +1,140 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestDelete(t *testing.T) {
+	inputMap := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("name", "app").
+			Set("version", "1").
+			Set("labels", orderedmap.NewMap().
+				Set("env", "dev").
+				Set("team name", "core")).
+			Set("items", []any{
+				orderedmap.NewMap().Set("id", int64(1)).Set("status", "old"),
+				orderedmap.NewMap().Set("id", int64(2)).Set("status", "new"),
+				orderedmap.NewMap().Set("id", int64(3)).Set("status", "old"),
+			}))
+	}
+	items := func(ids ...int64) []any {
+		status := map[int64]string{1: "old", 2: "new", 3: "old"}
+		res := make([]any, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, orderedmap.NewMap().Set("id", id).Set("status", status[id]))
+		}
+		return res
+	}
+
+	t.Run("map key", testCase{
+		inFn: inputMap,
+		s:    `del(version)`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("name", "app").
+				Set("labels", orderedmap.NewMap().
+					Set("env", "dev").
+					Set("team name", "core")).
+				Set("items", items(1, 2, 3)))
+		},
+	}.run)
+	t.Run("nested keys", testCase{
+		inFn: inputMap,
+		s:    `delete(labels["team name"], name, version)`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("labels", orderedmap.NewMap().Set("env", "dev")).
+				Set("items", items(1, 2, 3)))
+		},
+	}.run)
+	t.Run("slice index", testCase{
+		inFn: inputMap,
+		s:    `del(items[1]).items`,
+		outFn: func() *model.Value {
+			return model.NewValue(items(1, 3))
+		},
+	}.run)
+	t.Run("nested slice index", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", orderedmap.NewMap().Set("b", []any{int64(1), int64(2)}))),
+		s:   `del(a.b[0]).a.b`,
+		out: model.NewValue([]any{int64(2)}),
+	}.run)
+	t.Run("many slice indexes", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", orderedmap.NewMap().Set("b", []any{int64(1), int64(2), int64(3)}))),
+		s:   `del(a.b[0], a.b[1]).a.b`,
+		out: model.NewValue([]any{int64(3)}),
+	}.run)
+	t.Run("many slice indexes in any order", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", []any{int64(1), int64(2), int64(3), int64(4)})),
+		s:   `del(a[2], a[0], a[2]).a`,
+		out: model.NewValue([]any{int64(2), int64(4)}),
+	}.run)
+	t.Run("many slice indexes in go data", testCase{
+		in:  model.NewValue(map[string]any{"a": []any{int64(1), int64(2), int64(3)}}),
+		s:   `del(a[0], a[1]).a`,
+		out: model.NewValue([]any{int64(3)}),
+	}.run)
+	t.Run("negative slice index", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", []any{int64(1), int64(2), int64(3)})),
+		s:   `del(a[-1]).a`,
+		out: model.NewValue([]any{int64(1), int64(2)}),
+	}.run)
+	t.Run("filter", testCase{
+		inFn: inputMap,
+		s:    `del(items.filter(status == "old")).items`,
+		outFn: func() *model.Value {
+			return model.NewValue(items(2))
+		},
+	}.run)
+	t.Run("key within filter", testCase{
+		inFn: inputMap,
+		s:    `del(items.filter(id > 1)...status).items`,
+		outFn: func() *model.Value {
+			return model.NewValue([]any{
+				orderedmap.NewMap().Set("id", int64(1)).Set("status", "old"),
+				orderedmap.NewMap().Set("id", int64(2)),
+				orderedmap.NewMap().Set("id", int64(3)),
+			})
+		},
+	}.run)
+	t.Run("search", testCase{
+		inFn: inputMap,
+		s:    `del(search($key == "status" || $key == "env")).items`,
+		outFn: func() *model.Value {
+			return model.NewValue([]any{
+				orderedmap.NewMap().Set("id", int64(1)),
+				orderedmap.NewMap().Set("id", int64(2)),
+				orderedmap.NewMap().Set("id", int64(3)),
+			})
+		},
+	}.run)
+	t.Run("spread", testCase{
+		inFn: inputMap,
+		s:    `del(labels...).labels`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap())
+		},
+	}.run)
+	t.Run("missing key is ignored", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", int64(1))),
+		s:   `del(b)`,
+		out: model.NewValue(orderedmap.NewMap().Set("a", int64(1))),
+	}.run)
+	t.Run("root is modified", testCase{
+		inFn:        inputMap,
+		s:           `del(items)`,
+		compareRoot: true,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("name", "app").
+				Set("version", "1").
+				Set("labels", orderedmap.NewMap().
+					Set("env", "dev").
+					Set("team name", "core")))
+		},
+	}.run)
+}
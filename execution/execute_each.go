@@ -9,17 +9,18 @@ import (
 )
 
 func eachExprExecutor(e ast.EachExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "eachExpr")
 		if !data.IsSlice() {
 			return nil, fmt.Errorf("cannot each over non-array")
 		}
 
-		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
-
-			_, err := ExecuteAST(ctx, e.Expr, item, options)
+		if err := rangeChildren(ctx, options, data, func(itemOptions *Options, _ any, item *model.Value) error {
+			_, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
@@ -2,6 +2,7 @@ package execution_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -99,4 +100,70 @@ func TestErrors(t *testing.T) {
 			t.Fatal("expected error for count on non-array")
 		}
 	})
+
+	t.Run("unknown function suggestion", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `"a".toUpperr()`, model.NewNullValue(), execution.NewOptions())
+		var target execution.ErrUnknownFunction
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrUnknownFunction, got %v", err)
+		}
+		if target.Suggestion != "toUpper" {
+			t.Errorf("expected suggestion toUpper, got %q", target.Suggestion)
+		}
+	})
+
+	t.Run("unknown function suggests user defined functions", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `def double(x) = $x * 2; doubel(1)`, model.NewNullValue(), execution.NewOptions())
+		var target execution.ErrUnknownFunction
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrUnknownFunction, got %v", err)
+		}
+		if target.Suggestion != "double" {
+			t.Errorf("expected suggestion double, got %q", target.Suggestion)
+		}
+	})
+
+	t.Run("unknown function without suggestion", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `unknownFunc123()`, model.NewNullValue(), execution.NewOptions())
+		var target execution.ErrUnknownFunction
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrUnknownFunction, got %v", err)
+		}
+		if target.Suggestion != "" {
+			t.Errorf("expected no suggestion, got %q", target.Suggestion)
+		}
+	})
+
+	t.Run("missing key lists available keys", func(t *testing.T) {
+		in := model.NewMapValue()
+		_ = in.SetMapKey("name", model.NewStringValue("Tom"))
+		_ = in.SetMapKey("age", model.NewIntValue(30))
+		_, err := execution.ExecuteSelector(context.Background(), `nme`, in, execution.NewOptions())
+		var target model.MapKeyNotFound
+		if !errors.As(err, &target) {
+			t.Fatalf("expected MapKeyNotFound, got %v", err)
+		}
+		if strings.Join(target.Keys, ",") != "name,age" {
+			t.Errorf("unexpected keys: %v", target.Keys)
+		}
+	})
+
+	t.Run("errors carry the span of the failing expression", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `[1, 2].map($this + "x")`, model.NewNullValue(), execution.NewOptions())
+		var innermost execution.ExecutionError
+		for e := err; e != nil; e = errors.Unwrap(e) {
+			if execErr, ok := e.(execution.ExecutionError); ok {
+				if _, ok := execErr.Span(); ok {
+					innermost = execErr
+				}
+			}
+		}
+		span, ok := innermost.Span()
+		if !ok {
+			t.Fatalf("expected a span, got error %v", err)
+		}
+		if span.Pos != 17 || span.Len != 1 {
+			t.Errorf("expected span at 17 with length 1, got %+v", span)
+		}
+	})
 }
@@ -9,6 +9,10 @@ import (
 )
 
 func filterExprExecutor(e ast.FilterExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "filterExpr")
 		if !data.IsSlice() {
@@ -17,10 +21,10 @@ func filterExprExecutor(e ast.FilterExpr) (expressionExecutor, error) {
 		res := model.NewSliceValue()
 
 		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
+			trackPath(options, data, item, i)
+			itemOptions := withKeyVar(options, model.NewIntValue(int64(i)))
 
-			v, err := ExecuteAST(ctx, e.Expr, item, options)
+			v, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
@@ -2,18 +2,16 @@ package execution
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"slices"
 
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/selector/ast"
 )
 
-func prepareArgs(ctx context.Context, opts *Options, data *model.Value, argsE ast.Expressions) (model.Values, error) {
+func prepareArgs(ctx context.Context, opts *Options, data *model.Value, argsE []expressionExecutor) (model.Values, error) {
 	args := make(model.Values, 0)
 	for i, arg := range argsE {
-		res, err := ExecuteAST(ctx, arg, data, opts)
+		res, err := arg(ctx, opts, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating argument %d: %w", i, err)
 		}
@@ -28,7 +26,7 @@ func prepareArgs(ctx context.Context, opts *Options, data *model.Value, argsE as
 	return args, nil
 }
 
-func callFnExecutor(f FuncFn, argsE ast.Expressions) (expressionExecutor, error) {
+func callFnExecutor(f FuncFn, argsE []expressionExecutor) expressionExecutor {
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "callFnExpr")
 		args, err := prepareArgs(ctx, options, data, argsE)
@@ -42,24 +40,26 @@ func callFnExecutor(f FuncFn, argsE ast.Expressions) (expressionExecutor, error)
 		}
 
 		return res, nil
-	}, nil
-}
-
-var unstableFuncs = []string{
-	"ignore",
+	}
 }
 
-func callExprExecutor(options *Options, e ast.CallExpr) (expressionExecutor, error) {
-	if !options.Unstable && (slices.Contains(unstableFuncs, e.Function)) {
-		return nil, errors.New("unstable function are not enabled. to enable them use --unstable")
+func callExprExecutor(e ast.CallExpr) (expressionExecutor, error) {
+	args, err := compileASTs(e.Args)
+	if err != nil {
+		return nil, err
 	}
-	if f, ok := options.Funcs.Get(e.Function); ok {
-		res, err := callFnExecutor(f, e.Args)
-		if err != nil {
-			return nil, fmt.Errorf("error executing function %q: %w", e.Function, err)
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		// Functions are resolved when called, since they may be defined by earlier statements.
+		if def, ok := options.scope.lookupDef(e.Function); ok {
+			return callDefExecutor(def, args)(ctx, options, data)
+		}
+		if f, ok := options.Funcs.Get(e.Function); ok {
+			return callFnExecutor(f, args)(ctx, options, data)
 		}
-		return res, nil
-	}
 
-	return nil, fmt.Errorf("unknown function: %q", e.Function)
+		return nil, ErrUnknownFunction{
+			Name:       e.Function,
+			Suggestion: suggestName(e.Function, options.funcNames()),
+		}
+	}, nil
 }
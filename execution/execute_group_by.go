@@ -9,6 +9,10 @@ import (
 )
 
 func groupByExprExecutor(e ast.GroupByExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "groupByExpr")
 		if !data.IsSlice() {
@@ -18,10 +22,9 @@ func groupByExprExecutor(e ast.GroupByExpr) (expressionExecutor, error) {
 		res := model.NewMapValue()
 
 		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
+			itemOptions := withKeyVar(options, model.NewIntValue(int64(i)))
 
-			keyVal, err := ExecuteAST(ctx, e.Expr, item, options)
+			keyVal, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
@@ -62,30 +65,3 @@ func groupByExprExecutor(e ast.GroupByExpr) (expressionExecutor, error) {
 		return res, nil
 	}, nil
 }
-
-func valueToString(v *model.Value) (string, error) {
-	switch v.Type() {
-	case model.TypeString:
-		return v.StringValue()
-	case model.TypeInt:
-		i, err := v.IntValue()
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case model.TypeFloat:
-		f, err := v.FloatValue()
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%g", f), nil
-	case model.TypeBool:
-		b, err := v.BoolValue()
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%v", b), nil
-	default:
-		return "", fmt.Errorf("cannot convert %s to string for use as group key", v.Type())
-	}
-}
@@ -0,0 +1,116 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+func importExprExecutor(e ast.ImportExpr) (expressionExecutor, error) {
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "importExpr")
+		if err := importFile(ctx, options, e); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}, nil
+}
+
+// loadImports loads any imports that were given as options.
+func loadImports(ctx context.Context, options *Options) error {
+	for _, e := range options.imports {
+		if err := importFile(ctx, options, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importFile executes the selector file referenced by the import and exposes its
+// definitions and variables within the current scope, prefixed with "alias::".
+// The file is executed in its own root scope, so it cannot see Options.Vars or the importing selector's variables.
+func importFile(ctx context.Context, options *Options, e ast.ImportExpr) error {
+	if e.Alias == "" {
+		return fmt.Errorf("import %q requires an alias", e.Path)
+	}
+
+	stack := importStack(ctx)
+	dir := "."
+	if len(stack) > 0 {
+		dir = filepath.Dir(stack[len(stack)-1])
+	}
+	path, err := options.modules.resolve(dir, e.Path, options.LibPaths, func() (string, error) {
+		return resolveImportPath(dir, options.LibPaths, e.Path)
+	})
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(stack, path) {
+		return fmt.Errorf("import cycle: %s", strings.Join(append(slices.Clone(stack), path), " -> "))
+	}
+	ctx = context.WithValue(ctx, importStackCtxKey, append(slices.Clone(stack), path))
+
+	m, err := options.modules.load(path, e.Path)
+	if err != nil {
+		return err
+	}
+	if m.unstable && !options.Unstable {
+		return ModuleError{Path: path, Err: fmt.Errorf("error compiling import %q: %w", e.Path, errUnstable)}
+	}
+
+	// The module is executed in its own root scope, sharing value locations
+	// so that $path works within imported functions.
+	moduleOptions := options.withScope(nil, nil)
+	if _, err := m.executor(ctx, moduleOptions, model.NewNullValue()); err != nil {
+		return ModuleError{Path: path, Err: fmt.Errorf("error executing import %q: %w", e.Path, err)}
+	}
+
+	for name, fn := range moduleOptions.scope.defs {
+		if fn.module == "" {
+			fn.module = path
+		}
+		options.scope.setDef(e.Alias+"::"+name, fn)
+	}
+	for name, v := range moduleOptions.scope.vars {
+		options.scope.setVar(e.Alias+"::"+name, v)
+	}
+	return nil
+}
+
+// resolveImportPath returns the absolute path of the imported file.
+// Relative paths are resolved against dir, the directory of the importing file
+// (or the working directory for the top level selector), and then against each lib path.
+func resolveImportPath(dir string, libPaths []string, path string) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		candidates = append(candidates, filepath.Join(dir, path))
+		for _, lib := range libPaths {
+			candidates = append(candidates, filepath.Join(lib, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return "", fmt.Errorf("error resolving import %q: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		return filepath.Abs(candidate)
+	}
+	return "", fmt.Errorf("could not find import %q", path)
+}
@@ -0,0 +1,159 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func writeSelectorFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestImport(t *testing.T) {
+	dir := writeSelectorFiles(t, map[string]string{
+		"lib/k8s.dsl":    `import "util.dsl" as util; $prefix = "app-"; def name(x) = $prefix + util::slug($x)`,
+		"lib/util.dsl":   `def slug(x) = $x.toLower().replace(" ", "-")`,
+		"cycle/a.dsl":    `import "b.dsl" as b`,
+		"cycle/b.dsl":    `import "a.dsl" as a`,
+		"shared/str.dsl": `def shout(x) = $x.toUpper()`,
+		"unstable.dsl":   `def both() = branch(1, 2)`,
+		"vars.dsl":       `def greet() = $name`,
+	})
+
+	run := func(s string, opts ...execution.ExecuteOptionFn) (*model.Value, error) {
+		return execution.ExecuteSelector(context.Background(), s, model.NewNullValue(), execution.NewOptions(opts...))
+	}
+
+	t.Run("statement", func(t *testing.T) {
+		path := filepath.Join(dir, "lib", "k8s.dsl")
+		got, err := run(`import "` + path + `" as k8s; k8s::name("My Service")`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _ := got.StringValue(); s != "app-my-service" {
+			t.Errorf("unexpected result: %q", s)
+		}
+	})
+
+	t.Run("variables", func(t *testing.T) {
+		got, err := run(`$k8s::prefix`, execution.WithImport(filepath.Join(dir, "lib", "k8s.dsl"), "k8s"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _ := got.StringValue(); s != "app-" {
+			t.Errorf("unexpected result: %q", s)
+		}
+	})
+
+	t.Run("caller variables do not affect module", func(t *testing.T) {
+		got, err := run(`$prefix = "x"; k8s::name("a")`, execution.WithImport(filepath.Join(dir, "lib", "k8s.dsl"), "k8s"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _ := got.StringValue(); s != "app-a" {
+			t.Errorf("unexpected result: %q", s)
+		}
+	})
+
+	t.Run("lib paths", func(t *testing.T) {
+		got, err := run(`import "str.dsl" as str; str::shout("hi")`, execution.WithLibPaths(filepath.Join(dir, "shared")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, _ := got.StringValue(); s != "HI" {
+			t.Errorf("unexpected result: %q", s)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := run(`import "missing.dsl" as m; 1`)
+		if err == nil || !strings.Contains(err.Error(), `could not find import "missing.dsl"`) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := run(`1`, execution.WithImport(filepath.Join(dir, "cycle", "a.dsl"), "a"))
+		if err == nil || !strings.Contains(err.Error(), "import cycle: ") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("definitions are namespaced", func(t *testing.T) {
+		_, err := run(`shout("hi")`, execution.WithImport(filepath.Join(dir, "shared", "str.dsl"), "str"))
+		if err == nil || !strings.Contains(err.Error(), `unknown function: "shout"`) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unstable features", func(t *testing.T) {
+		path := filepath.Join(dir, "unstable.dsl")
+		if _, err := run(`1`, execution.WithImport(path, "u")); err == nil || !strings.Contains(err.Error(), "unstable features are not enabled") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := run(`1`, execution.WithImport(path, "u"), execution.WithUnstable()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors within imported functions", func(t *testing.T) {
+		path := filepath.Join(dir, "shared", "str.dsl")
+		_, err := run(`str::shout(1)`, execution.WithImport(path, "str"))
+		var moduleErr execution.ModuleError
+		if !errors.As(err, &moduleErr) {
+			t.Fatalf("expected ModuleError, got %v", err)
+		}
+		if moduleErr.Path != path {
+			t.Errorf("expected path %q, got %q", path, moduleErr.Path)
+		}
+	})
+
+	t.Run("module cannot see caller options vars", func(t *testing.T) {
+		_, err := run(`v::greet()`, execution.WithImport(filepath.Join(dir, "vars.dsl"), "v"), execution.WithVariable("name", model.NewStringValue("Tom")))
+		if err == nil || !strings.Contains(err.Error(), "variable name not found") {
+			t.Errorf("expected an undefined variable error, got %v", err)
+		}
+	})
+
+	t.Run("files are read once per program", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "once.dsl")
+		if err := os.WriteFile(path, []byte(`$v = 1`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		program, err := execution.CompileSelector(`import "` + path + `" as once; $once::v`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			got, err := program.Execute(context.Background(), model.NewNullValue(), execution.NewOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n, _ := got.IntValue(); n != 1 {
+				t.Errorf("execution %d: expected 1, got %d", i, n)
+			}
+			// Later executions must use the compiled module rather than reading the file again.
+			if err := os.WriteFile(path, []byte(`$v = 2`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
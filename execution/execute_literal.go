@@ -2,6 +2,9 @@ package execution
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/selector/ast"
 )
@@ -27,6 +30,29 @@ func stringExprExecutor(e ast.StringExpr) (expressionExecutor, error) {
 	}, nil
 }
 
+func interpolatedStringExprExecutor(e ast.InterpolatedStringExpr) (expressionExecutor, error) {
+	parts, err := compileASTs(e.Parts)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "interpolatedStringExpr")
+		var buf strings.Builder
+		for _, part := range parts {
+			v, err := part(ctx, options, data)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating string interpolation: %w", err)
+			}
+			s, err := valueToString(v)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating string interpolation: %w", err)
+			}
+			buf.WriteString(s)
+		}
+		return model.NewStringValue(buf.String()), nil
+	}, nil
+}
+
 func boolExprExecutor(e ast.BoolExpr) (expressionExecutor, error) {
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		//ctx = WithExecutorID(ctx, "boolExpr")
@@ -1,9 +1,13 @@
 package execution_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
 )
 
 func TestLiteral(t *testing.T) {
@@ -111,3 +115,54 @@ func TestLiteral(t *testing.T) {
 		},
 	}.run)
 }
+
+func TestInterpolatedString(t *testing.T) {
+	in := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("name", "api").
+			Set("region", "eu-west-1").
+			Set("replicas", int64(3)).
+			Set("ratio", 0.5).
+			Set("enabled", true).
+			Set("tags", []any{"a", "b"}))
+	}
+	t.Run("properties", testCase{
+		inFn: in,
+		s:    `"host-${name}.${region}.example.com"`,
+		out:  model.NewStringValue("host-api.eu-west-1.example.com"),
+	}.run)
+	t.Run("scalars are stringified", testCase{
+		inFn: in,
+		s:    `"${replicas} ${ratio} ${enabled}"`,
+		out:  model.NewStringValue("3 0.5 true"),
+	}.run)
+	t.Run("expressions", testCase{
+		inFn: in,
+		s:    `"${name.toUpper()}-${replicas * 2}-${tags.join(",")}"`,
+		out:  model.NewStringValue("API-6-a,b"),
+	}.run)
+	t.Run("this and variables", testCase{
+		inFn: in,
+		s:    `tags.map("${$this}-${$suffix}")`,
+		opts: []execution.ExecuteOptionFn{
+			execution.WithVariable("suffix", model.NewStringValue("prod")),
+		},
+		out: model.NewValue([]any{"a-prod", "b-prod"}),
+	}.run)
+	t.Run("escaped", testCase{
+		inFn: in,
+		s:    `"\${name}"`,
+		out:  model.NewStringValue("${name}"),
+	}.run)
+	t.Run("single quoted", testCase{
+		inFn: in,
+		s:    `'${name}'`,
+		out:  model.NewStringValue("${name}"),
+	}.run)
+	t.Run("non scalar", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `"${tags}"`, in(), execution.NewOptions())
+		if err == nil || !strings.Contains(err.Error(), "cannot convert array to string") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
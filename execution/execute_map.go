@@ -9,6 +9,10 @@ import (
 )
 
 func mapExprExecutor(e ast.MapExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "mapExpr")
 		if !data.IsSlice() {
@@ -16,11 +20,8 @@ func mapExprExecutor(e ast.MapExpr) (expressionExecutor, error) {
 		}
 		res := model.NewSliceValue()
 
-		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
-
-			item, err := ExecuteAST(ctx, e.Expr, item, options)
+		if err := rangeChildren(ctx, options, data, func(itemOptions *Options, _ any, item *model.Value) error {
+			item, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
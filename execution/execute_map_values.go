@@ -9,6 +9,10 @@ import (
 )
 
 func mapValuesExprExecutor(e ast.MapValuesExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "mapValuesExpr")
 		if !data.IsMap() {
@@ -18,10 +22,9 @@ func mapValuesExprExecutor(e ast.MapValuesExpr) (expressionExecutor, error) {
 		res := model.NewMapValue()
 
 		if err := data.RangeMap(func(key string, value *model.Value) error {
-			restore := withKeyVar(options, model.NewStringValue(key))
-			defer restore()
+			itemOptions := withKeyVar(options, model.NewStringValue(key))
 
-			transformed, err := ExecuteAST(ctx, e.Expr, value, options)
+			transformed, err := expr(ctx, itemOptions, value)
 			if err != nil {
 				return fmt.Errorf("error evaluating mapValues expr for key %q: %w", key, err)
 			}
@@ -2,29 +2,49 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/selector/ast"
 )
 
+// objectPair is a key-value pair of an object expression with its executors already built.
+type objectPair struct {
+	spread bool
+	key    expressionExecutor
+	value  expressionExecutor
+}
+
 func objectExprExecutor(e ast.ObjectExpr) (expressionExecutor, error) {
+	pairs := make([]objectPair, len(e.Pairs))
+	for i, p := range e.Pairs {
+		pair := objectPair{spread: ast.IsType[ast.SpreadExpr](p.Key)}
+		var err error
+		if !pair.spread {
+			pair.key, err = compileAST(p.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// A spread without a value spreads the input data.
+		pair.value, err = compileAST(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = pair
+	}
+
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "objectExpr")
 		obj := model.NewMapValue()
-		for _, p := range e.Pairs {
+		for _, p := range pairs {
 
-			if ast.IsType[ast.SpreadExpr](p.Key) {
-				var val *model.Value
-				var err error
-				if p.Value != nil {
-					// We need to spread the resulting value.
-					val, err = ExecuteAST(ctx, p.Value, data, options)
-					if err != nil {
-						return nil, fmt.Errorf("error evaluating spread values: %w", err)
-					}
-				} else {
-					val = data
+			if p.spread {
+				// We need to spread the resulting value.
+				val, err := p.value(ctx, options, data)
+				if err != nil {
+					return nil, fmt.Errorf("error evaluating spread values: %w", err)
 				}
 
 				if err := val.RangeMap(func(key string, value *model.Value) error {
@@ -38,7 +58,7 @@ func objectExprExecutor(e ast.ObjectExpr) (expressionExecutor, error) {
 				continue
 			}
 
-			key, err := ExecuteAST(ctx, p.Key, data, options)
+			key, err := p.key(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error evaluating key: %w", err)
 			}
@@ -46,7 +66,7 @@ func objectExprExecutor(e ast.ObjectExpr) (expressionExecutor, error) {
 				return nil, fmt.Errorf("expected key to resolve to string, got %s", key.Type())
 			}
 
-			val, err := ExecuteAST(ctx, p.Value, data, options)
+			val, err := p.value(ctx, options, data)
 			if err != nil {
 				return nil, fmt.Errorf("error evaluating value: %w", err)
 			}
@@ -64,9 +84,13 @@ func objectExprExecutor(e ast.ObjectExpr) (expressionExecutor, error) {
 }
 
 func propertyExprExecutor(e ast.PropertyExpr) (expressionExecutor, error) {
+	property, err := compileAST(e.Property)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "propertyExpr")
-		key, err := ExecuteAST(ctx, e.Property, data, options)
+		key, err := property(ctx, options, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating property: %w", err)
 		}
@@ -77,13 +101,26 @@ func propertyExprExecutor(e ast.PropertyExpr) (expressionExecutor, error) {
 				return nil, fmt.Errorf("error getting string value: %w", err)
 			}
 
-			return data.GetMapKey(keyStr)
+			res, err := data.GetMapKey(keyStr)
+			if err != nil {
+				var notFound model.MapKeyNotFound
+				if errors.As(err, &notFound) {
+					notFound.Keys, _ = data.MapKeys()
+					return nil, notFound
+				}
+				return nil, err
+			}
+			return trackPath(options, data, res, keyStr), nil
 		case key.IsInt():
 			keyInt, err := key.IntValue()
 			if err != nil {
 				return nil, fmt.Errorf("error getting int value: %w", err)
 			}
-			return data.GetSliceIndex(int(keyInt))
+			res, err := data.GetSliceIndex(int(keyInt))
+			if err != nil {
+				return nil, err
+			}
+			return trackPath(options, data, res, int(keyInt)), nil
 		default:
 			return nil, fmt.Errorf("expected key to be a string or int, got %s", key.Type())
 		}
@@ -0,0 +1,84 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestPathVariable(t *testing.T) {
+	in := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("users", []any{
+				orderedmap.NewMap().Set("name", "Tom").Set("admin", true),
+				orderedmap.NewMap().Set("name", "Jim").Set("admin", false),
+			}).
+			Set("owner", orderedmap.NewMap().Set("name", "Tom")))
+	}
+
+	t.Run("root", testCase{
+		inFn: in,
+		s:    `$path`,
+		out:  model.NewSliceValue(),
+	}.run)
+	t.Run("property and index", testCase{
+		inFn: in,
+		s:    `users[1].name.$path`,
+		out:  model.NewValue([]any{"users", int64(1), "name"}),
+	}.run)
+	t.Run("filter", testCase{
+		inFn: in,
+		s:    `users.filter(admin).map($path)`,
+		out:  model.NewValue([]any{[]any{"users", int64(0)}}),
+	}.run)
+	t.Run("spread", testCase{
+		inFn: in,
+		s:    `[users...].map($path)`,
+		out:  model.NewValue([]any{[]any{"users", int64(0)}, []any{"users", int64(1)}}),
+	}.run)
+	t.Run("map", testCase{
+		inFn: in,
+		s:    `users.map($path)`,
+		out:  model.NewValue([]any{[]any{"users", int64(0)}, []any{"users", int64(1)}}),
+	}.run)
+	t.Run("each", testCase{
+		inFn: in,
+		s:    `users.each(name = $path[1]).map(name)`,
+		out:  model.NewValue([]any{int64(0), int64(1)}),
+	}.run)
+	t.Run("sortBy", testCase{
+		inFn: in,
+		s:    `users.sortBy(name).map($path)`,
+		out:  model.NewValue([]any{[]any{"users", int64(1)}, []any{"users", int64(0)}}),
+	}.run)
+	t.Run("sortBy expression", testCase{
+		inFn: in,
+		s:    `users.sortBy($path[1], desc).map(name)`,
+		out:  model.NewValue([]any{"Jim", "Tom"}),
+	}.run)
+	t.Run("search", testCase{
+		inFn: in,
+		s:    `search(name == "Tom").map($path)`,
+		out:  model.NewValue([]any{[]any{"users", int64(0)}, []any{"owner"}}),
+	}.run)
+	t.Run("recursive descent", testCase{
+		inFn: in,
+		s:    `..name.map($path)`,
+		out: model.NewValue([]any{
+			[]any{"users", int64(0), "name"},
+			[]any{"users", int64(1), "name"},
+			[]any{"owner", "name"},
+		}),
+	}.run)
+	t.Run("edit found value", testCase{
+		inFn: in,
+		s:    `setPath(search(name == "Jim").first().$path, {"name": "James"})`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("users", []any{
+				orderedmap.NewMap().Set("name", "Tom").Set("admin", true),
+				orderedmap.NewMap().Set("name", "James"),
+			}).
+			Set("owner", orderedmap.NewMap().Set("name", "Tom"))),
+	}.run)
+}
@@ -8,9 +8,13 @@ import (
 )
 
 func recursiveDescentExprExecutor2(e ast.RecursiveDescentExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	var doSearch func(ctx context.Context, options *Options, data *model.Value) ([]*model.Value, error)
 	findValue := func(ctx context.Context, options *Options, v *model.Value) (*model.Value, error) {
-		property, err := ExecuteAST(ctx, e.Expr, v, options)
+		property, err := expr(ctx, options, v)
 		if err != nil {
 			handleErrs := []any{
 				model.ErrIncompatibleTypes{},
@@ -35,67 +39,32 @@ func recursiveDescentExprExecutor2(e ast.RecursiveDescentExpr) (expressionExecut
 	doSearch = func(ctx context.Context, options *Options, data *model.Value) ([]*model.Value, error) {
 		res := make([]*model.Value, 0)
 
-		switch data.Type() {
-		case model.TypeMap:
-			if err := data.RangeMap(func(key string, v *model.Value) error {
-				restore := withKeyVar(options, model.NewStringValue(key))
-				defer restore()
-
-				if v.IsScalar() {
-					if e.IsWildcard {
-						res = append(res, v)
-					}
-				} else {
-					if !e.IsWildcard {
-						property, err := findValue(ctx, options, v)
-						if err != nil {
-							return err
-						}
-						if property != nil {
-							res = append(res, property)
-						}
-					}
-
-					gotNext, err := doSearch(ctx, options, v)
-					if err != nil {
-						return err
-					}
-					res = append(res, gotNext...)
+		if err := rangeChildren(ctx, options, data, func(itemOptions *Options, _ any, v *model.Value) error {
+			if v.IsScalar() {
+				if e.IsWildcard {
+					res = append(res, v)
 				}
 				return nil
-			}); err != nil {
-				return nil, err
 			}
-		case model.TypeSlice:
-			if err := data.RangeSlice(func(i int, v *model.Value) error {
-				restore := withKeyVar(options, model.NewIntValue(int64(i)))
-				defer restore()
 
-				if v.IsScalar() {
-					if e.IsWildcard {
-						res = append(res, v)
-					}
-				} else {
-					if !e.IsWildcard {
-						property, err := findValue(ctx, options, v)
-						if err != nil {
-							return err
-						}
-						if property != nil {
-							res = append(res, property)
-						}
-					}
-
-					gotNext, err := doSearch(ctx, options, v)
-					if err != nil {
-						return err
-					}
-					res = append(res, gotNext...)
+			if !e.IsWildcard {
+				property, err := findValue(ctx, itemOptions, v)
+				if err != nil {
+					return err
+				}
+				if property != nil {
+					res = append(res, property)
 				}
-				return nil
-			}); err != nil {
-				return nil, err
 			}
+
+			gotNext, err := doSearch(ctx, options, v)
+			if err != nil {
+				return err
+			}
+			res = append(res, gotNext...)
+			return nil
+		}); err != nil {
+			return nil, err
 		}
 
 		return res, nil
@@ -119,3 +88,29 @@ func recursiveDescentExprExecutor2(e ast.RecursiveDescentExpr) (expressionExecut
 		return matches, nil
 	}, nil
 }
+
+// rangeChildren calls fn with each child of a map or slice, along with its key or index.
+// fn is given options with $key set to the key or index of the child, and the path of each child is tracked.
+// Other types have no children. Ranging stops if the context is done.
+func rangeChildren(ctx context.Context, options *Options, data *model.Value, fn func(itemOptions *Options, key any, v *model.Value) error) error {
+	switch data.Type() {
+	case model.TypeMap:
+		return data.RangeMap(func(key string, v *model.Value) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			trackPath(options, data, v, key)
+			return fn(withKeyVar(options, model.NewStringValue(key)), key, v)
+		})
+	case model.TypeSlice:
+		return data.RangeSlice(func(i int, v *model.Value) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			trackPath(options, data, v, i)
+			return fn(withKeyVar(options, model.NewIntValue(int64(i))), i, v)
+		})
+	default:
+		return nil
+	}
+}
@@ -9,6 +9,18 @@ import (
 )
 
 func reduceExprExecutor(e ast.ReduceExpr) (expressionExecutor, error) {
+	initExec, err := compileAST(e.Init)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	update, err := compileAST(e.Update)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "reduceExpr")
 		if !data.IsSlice() {
@@ -16,37 +28,23 @@ func reduceExprExecutor(e ast.ReduceExpr) (expressionExecutor, error) {
 		}
 
 		// Evaluate init expression to get the initial accumulator value.
-		acc, err := ExecuteAST(ctx, e.Init, data, options)
+		acc, err := initExec(ctx, options, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating reduce init: %w", err)
 		}
 
-		// Save and restore the acc variable to avoid polluting the caller's scope.
-		prevAcc, hadAcc := options.Vars["acc"]
-		defer func() {
-			if hadAcc {
-				options.Vars["acc"] = prevAcc
-			} else {
-				delete(options.Vars, "acc")
-			}
-		}()
-
 		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
+			itemOptions := withKeyVar(options, model.NewIntValue(int64(i)))
 
 			// Evaluate the per-element expression against the item.
-			elemVal, err := ExecuteAST(ctx, e.Expr, item, options)
+			elemVal, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return fmt.Errorf("error evaluating reduce expr for element %d: %w", i, err)
 			}
 
-			// Set $acc to the current accumulator and $this to the element value.
-			options.Vars["acc"] = acc
-			options.Vars["this"] = elemVal
-
-			// Evaluate the update expression.
-			newAcc, err := ExecuteAST(ctx, e.Update, elemVal, options)
+			// Evaluate the update expression against the element value, with $acc set to the current accumulator.
+			updateOptions := itemOptions.withScope(itemOptions.scope, map[string]*model.Value{"acc": acc})
+			newAcc, err := update(ctx, updateOptions, elemVal)
 			if err != nil {
 				return fmt.Errorf("error evaluating reduce update for element %d: %w", i, err)
 			}
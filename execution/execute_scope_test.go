@@ -0,0 +1,102 @@
+package execution_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestScope(t *testing.T) {
+	intSlice := func() *model.Value {
+		s := model.NewSliceValue()
+		_ = s.Append(model.NewIntValue(1))
+		_ = s.Append(model.NewIntValue(2))
+		return s
+	}
+
+	t.Run("nested $key is scoped to the inner map", testCase{
+		inFn: func() *model.Value {
+			s := model.NewSliceValue()
+			_ = s.Append(intSlice())
+			_ = s.Append(intSlice())
+			return s
+		},
+		s: `map(sum(map($key)...) + $key * 10)`,
+		outFn: func() *model.Value {
+			s := model.NewSliceValue()
+			_ = s.Append(model.NewIntValue(1))
+			_ = s.Append(model.NewIntValue(11))
+			return s
+		},
+	}.run)
+
+	t.Run("assignment to outer variable within map", testCase{
+		s:   `$total = 0; [1, 2].each($total = $total + $this); $total`,
+		out: model.NewIntValue(3),
+	}.run)
+
+	t.Run("variables declared within map do not leak", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `map($inner = $this); $inner`, intSlice(), execution.NewOptions())
+		if err == nil || !strings.Contains(err.Error(), "variable inner not found") {
+			t.Errorf("expected variable not found error, got %v", err)
+		}
+	})
+
+	t.Run("function parameters do not leak", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `def f(p) = $p; f(1); $p`, model.NewNullValue(), execution.NewOptions())
+		if err == nil || !strings.Contains(err.Error(), "variable p not found") {
+			t.Errorf("expected variable not found error, got %v", err)
+		}
+	})
+
+	t.Run("function body uses the scope it was defined in", testCase{
+		s:   `$x = 1; def f() = $x; def g(x) = f(); g(2)`,
+		out: model.NewIntValue(1),
+	}.run)
+
+	t.Run("options are not modified", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithVariable("x", model.NewIntValue(1)))
+		_, err := execution.ExecuteSelector(context.Background(), `$y = 2; def f() = 1; f()`, model.NewNullValue(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(opts.Vars) != 1 {
+			t.Errorf("expected options vars to be unchanged, got %v", opts.Vars)
+		}
+		if _, err := execution.ExecuteSelector(context.Background(), `f()`, model.NewNullValue(), opts); err == nil {
+			t.Errorf("expected function to not be defined")
+		}
+	})
+
+	t.Run("options shared between concurrent executions", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithVariable("offset", model.NewIntValue(100)))
+		var wg sync.WaitGroup
+		for i := int64(0); i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				in := model.NewSliceValue()
+				_ = in.Append(model.NewIntValue(i))
+				_ = in.Append(model.NewIntValue(i + 1))
+				res, err := execution.ExecuteSelector(context.Background(), `def f(n) = $n + $offset; sum(map(f($this) + $key)...)`, in, opts)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				got, err := res.IntValue()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if exp := 2*i + 202; got != exp {
+					t.Errorf("expected %d, got %d", exp, got)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
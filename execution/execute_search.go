@@ -8,48 +8,20 @@ import (
 )
 
 func searchExprExecutor(e ast.SearchExpr) (expressionExecutor, error) {
-	var doSearch func(ctx context.Context, options *Options, data *model.Value) ([]*model.Value, error)
-	processValue := func(ctx context.Context, v *model.Value, options *Options) (bool, error) {
-		got, err := ExecuteAST(ctx, e.Expr, v, options)
-		if err != nil {
-			handleErrs := []any{
-				model.ErrIncompatibleTypes{},
-				model.ErrUnexpectedType{},
-				model.ErrUnexpectedTypes{},
-				model.SliceIndexOutOfRange{},
-				model.MapKeyNotFound{},
-			}
-			for _, e := range handleErrs {
-				if errors.As(err, &e) {
-					err = nil
-					break
-				}
-			}
-		}
-		if err != nil {
-			return false, err
-		}
-
-		if got == nil {
-			return false, nil
-		}
-
-		gotV, err := got.BoolValue()
-		if err != nil {
-			return false, err
-		}
-		return gotV, nil
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
 	}
+
+	var doSearch func(ctx context.Context, options *Options, data *model.Value) ([]*model.Value, error)
 	doSearch = func(ctx context.Context, options *Options, data *model.Value) ([]*model.Value, error) {
 		res := make([]*model.Value, 0)
 
 		switch data.Type() {
 		case model.TypeMap:
 			if err := data.RangeMap(func(key string, v *model.Value) error {
-				restore := withKeyVar(options, model.NewStringValue(key))
-				defer restore()
-
-				match, err := processValue(ctx, v, options)
+				trackPath(options, data, v, key)
+				match, err := searchMatches(ctx, expr, v, withKeyVar(options, model.NewStringValue(key)))
 				if err != nil {
 					return err
 				}
@@ -70,10 +42,8 @@ func searchExprExecutor(e ast.SearchExpr) (expressionExecutor, error) {
 			}
 		case model.TypeSlice:
 			if err := data.RangeSlice(func(i int, v *model.Value) error {
-				restore := withKeyVar(options, model.NewIntValue(int64(i)))
-				defer restore()
-
-				match, err := processValue(ctx, v, options)
+				trackPath(options, data, v, i)
+				match, err := searchMatches(ctx, expr, v, withKeyVar(options, model.NewIntValue(int64(i))))
 				if err != nil {
 					return err
 				}
@@ -115,3 +85,37 @@ func searchExprExecutor(e ast.SearchExpr) (expressionExecutor, error) {
 		return matches, nil
 	}, nil
 }
+
+// searchMatches reports whether the value matches the search expression.
+// Type and lookup errors are treated as a non-match.
+func searchMatches(ctx context.Context, expr expressionExecutor, v *model.Value, options *Options) (bool, error) {
+	got, err := expr(ctx, options, v)
+	if err != nil {
+		handleErrs := []any{
+			model.ErrIncompatibleTypes{},
+			model.ErrUnexpectedType{},
+			model.ErrUnexpectedTypes{},
+			model.SliceIndexOutOfRange{},
+			model.MapKeyNotFound{},
+		}
+		for _, e := range handleErrs {
+			if errors.As(err, &e) {
+				err = nil
+				break
+			}
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if got == nil {
+		return false, nil
+	}
+
+	gotV, err := got.BoolValue()
+	if err != nil {
+		return false, err
+	}
+	return gotV, nil
+}
@@ -10,6 +10,10 @@ import (
 )
 
 func sortByExprExecutor(e ast.SortByExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "sortByExpr")
 		if !data.IsSlice() {
@@ -22,16 +26,13 @@ func sortByExprExecutor(e ast.SortByExpr) (expressionExecutor, error) {
 		}
 		values := make([]sortableValue, 0)
 
-		if err := data.RangeSlice(func(i int, item *model.Value) error {
-			restore := withKeyVar(options, model.NewIntValue(int64(i)))
-			defer restore()
-
-			item, err := ExecuteAST(ctx, e.Expr, item, options)
+		if err := rangeChildren(ctx, options, data, func(itemOptions *Options, key any, item *model.Value) error {
+			item, err := expr(ctx, itemOptions, item)
 			if err != nil {
 				return err
 			}
 			values = append(values, sortableValue{
-				index: i,
+				index: key.(int),
 				value: item,
 			})
 			return nil
@@ -57,7 +58,7 @@ func sortByExprExecutor(e ast.SortByExpr) (expressionExecutor, error) {
 			if err != nil {
 				return nil, fmt.Errorf("error getting slice index: %w", err)
 			}
-			if err := res.Append(item); err != nil {
+			if err := res.Append(trackPath(options, data, item, i.index)); err != nil {
 				return nil, fmt.Errorf("error appending item to result: %w", err)
 			}
 		}
@@ -17,7 +17,7 @@ func spreadExprExecutor() (expressionExecutor, error) {
 		switch {
 		case data.IsSlice():
 			if err := data.RangeSlice(func(key int, value *model.Value) error {
-				if err := s.Append(value); err != nil {
+				if err := s.Append(trackPath(options, data, value, key)); err != nil {
 					return fmt.Errorf("error appending value to slice: %w", err)
 				}
 				return nil
@@ -26,7 +26,7 @@ func spreadExprExecutor() (expressionExecutor, error) {
 			}
 		case data.IsMap():
 			if err := data.RangeMap(func(key string, value *model.Value) error {
-				if err := s.Append(value); err != nil {
+				if err := s.Append(trackPath(options, data, value, key)); err != nil {
 					return fmt.Errorf("error appending value to slice: %w", err)
 				}
 				return nil
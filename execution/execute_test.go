@@ -2,6 +2,7 @@ package execution_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -145,5 +146,52 @@ func TestExecuteSelector_HappyPath(t *testing.T) {
 			},
 			compareRoot: true,
 		}.run)
+
+		t.Run("set missing property", testCase{
+			in: inputMap(),
+			s:  `name.middle = "J"`,
+			outFn: func() *model.Value {
+				res := inputMap()
+				name, err := res.GetMapKey("name")
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if err := name.SetMapKey("middle", model.NewStringValue("J")); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return res
+			},
+			compareRoot: true,
+		}.run)
+
+		t.Run("set index at length appends", testCase{
+			in: inputSlice(),
+			s:  `$this[3] = 4`,
+			outFn: func() *model.Value {
+				res := inputSlice()
+				if err := res.Append(model.NewIntValue(4)); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return res
+			},
+			compareRoot: true,
+		}.run)
+
+		t.Run("set spread", testCase{
+			in: inputSlice(),
+			s:  `$this... = 0`,
+			outFn: func() *model.Value {
+				return model.NewValue([]any{0, 0, 0})
+			},
+			compareRoot: true,
+		}.run)
+
+		t.Run("set property of missing parent", func(t *testing.T) {
+			_, err := execution.ExecuteSelector(t.Context(), `address.city = "x"`, inputMap(), execution.NewOptions())
+			var notFound model.MapKeyNotFound
+			if !errors.As(err, &notFound) || notFound.Key != "address" {
+				t.Errorf("expected address not found, got %v", err)
+			}
+		})
 	})
 }
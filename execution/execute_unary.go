@@ -10,9 +10,13 @@ import (
 )
 
 func unaryExprExecutor(e ast.UnaryExpr) (expressionExecutor, error) {
+	rightExec, err := compileAST(e.Right)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
 		ctx = WithExecutorID(ctx, "unaryExpr")
-		right, err := ExecuteAST(ctx, e.Right, data, options)
+		right, err := rightExec(ctx, options, data)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating right expression: %w", err)
 		}
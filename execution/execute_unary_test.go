@@ -1,8 +1,10 @@
 package execution_test
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/model/orderedmap"
 )
@@ -72,5 +74,23 @@ func TestUnary(t *testing.T) {
 				out:  model.NewBoolValue(true),
 			}.run)
 		})
+		t.Run("binary", func(t *testing.T) {
+			t.Run("not binds tighter than and", testCase{
+				s:   `!true && true`,
+				out: model.NewBoolValue(false),
+			}.run)
+			t.Run("not within array", testCase{
+				s:   `len([!true, false])`,
+				out: model.NewIntValue(2),
+			}.run)
+			t.Run("not binds tighter than equal", func(t *testing.T) {
+				in := model.NewValue(orderedmap.NewMap().Set("x", int64(1)))
+				_, err := execution.ExecuteSelector(t.Context(), `!x == 1`, in, execution.NewOptions())
+				var unexpected model.ErrUnexpectedType
+				if !errors.As(err, &unexpected) {
+					t.Errorf("expected ErrUnexpectedType from negating an int, got %v", err)
+				}
+			})
+		})
 	})
 }
@@ -0,0 +1,62 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+func walkExprExecutor(e ast.WalkExpr) (expressionExecutor, error) {
+	expr, err := compileAST(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	var walk func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error)
+	walk = func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		node := data
+		switch data.Type() {
+		case model.TypeMap:
+			node = model.NewMapValue()
+		case model.TypeSlice:
+			node = model.NewSliceValue()
+		}
+		if node != data {
+			// Metadata such as the source format's styling is kept with the rebuilt value.
+			node.Metadata = maps.Clone(data.Metadata)
+		}
+
+		// Children are replaced before their parent, so the expression sees the transformed children.
+		if err := rangeChildren(ctx, options, data, func(itemOptions *Options, key any, v *model.Value) error {
+			res, err := walk(ctx, itemOptions, v)
+			if err != nil {
+				return err
+			}
+			if k, ok := key.(string); ok {
+				return node.SetMapKey(k, res)
+			}
+			return node.Append(res)
+		}); err != nil {
+			return nil, err
+		}
+
+		if node != data {
+			if path, ok := options.paths[data]; ok {
+				options.paths[node] = path
+			}
+		}
+
+		res, err := expr(ctx, options, node)
+		if err != nil {
+			return nil, fmt.Errorf("error walking value: %w", err)
+		}
+		return res, nil
+	}
+
+	return func(ctx context.Context, options *Options, data *model.Value) (*model.Value, error) {
+		ctx = WithExecutorID(ctx, "walkExpr")
+		return walk(ctx, options, data)
+	}, nil
+}
@@ -0,0 +1,96 @@
+package execution_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestWalk(t *testing.T) {
+	in := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("Name", " api ").
+			Set("Owner", nil).
+			Set("Tags", []any{" a", nil, "b "}).
+			Set("Spec", orderedmap.NewMap().
+				Set("Replicas", int64(2)).
+				Set("Image", nil)))
+	}
+
+	t.Run("trim strings", testCase{
+		inFn: in,
+		s:    `walk(if (typeOf($this) == "string") { $this.trim() } else { $this })`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("Name", "api").
+			Set("Owner", nil).
+			Set("Tags", []any{"a", nil, "b"}).
+			Set("Spec", orderedmap.NewMap().
+				Set("Replicas", int64(2)).
+				Set("Image", nil))),
+	}.run)
+	t.Run("strip nulls", testCase{
+		inFn: in,
+		s: `walk(
+			if (typeOf($this) == "map") { $this.entries().filter(typeOf(value) != "null").fromEntries() }
+			elseif (typeOf($this) == "array") { $this.filter(typeOf($this) != "null") }
+			else { $this }
+		)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("Name", " api ").
+			Set("Tags", []any{" a", "b "}).
+			Set("Spec", orderedmap.NewMap().
+				Set("Replicas", int64(2)))),
+	}.run)
+	t.Run("lower case keys", testCase{
+		inFn: in,
+		s:    `walk(if (typeOf($this) == "map") { $this.entries().map({"key": key.toLower(), value}).fromEntries() } else { $this })`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("name", " api ").
+			Set("owner", nil).
+			Set("tags", []any{" a", nil, "b "}).
+			Set("spec", orderedmap.NewMap().
+				Set("replicas", int64(2)).
+				Set("image", nil))),
+	}.run)
+	t.Run("bottom up", testCase{
+		s:   `walk(if (typeOf($this) == "array") { len($this) } elseif (typeOf($this) == "int") { [$this, $this] } else { $this })`,
+		in:  model.NewValue([]any{int64(1), int64(2)}),
+		out: model.NewIntValue(2),
+	}.run)
+	t.Run("key variable", testCase{
+		in:  model.NewValue(orderedmap.NewMap().Set("a", int64(1)).Set("b", int64(2))),
+		s:   `walk(if (typeOf($this) == "int") { $key } else { $this })`,
+		out: model.NewValue(orderedmap.NewMap().Set("a", "a").Set("b", "b")),
+	}.run)
+	t.Run("scalar", testCase{
+		s:   `"x".walk(($this + "y"))`,
+		out: model.NewStringValue("xy"),
+	}.run)
+	t.Run("keeps metadata", func(t *testing.T) {
+		spec := model.NewValue(orderedmap.NewMap().Set("image", "api"))
+		spec.SetMetadataValue("style", "block")
+		in := model.NewMapValue()
+		in.SetMetadataValue("style", "flow")
+		if err := in.SetMapKey("spec", spec); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := execution.ExecuteSelector(context.Background(), `walk($this)`, in, execution.NewOptions())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if style, _ := res.MetadataValue("style"); style != "flow" {
+			t.Errorf("expected root style flow, got %v", style)
+		}
+		spec, err = res.GetMapKey("spec")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if style, _ := spec.MetadataValue("style"); style != "block" {
+			t.Errorf("expected nested style block, got %v", style)
+		}
+	})
+}
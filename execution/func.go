@@ -3,6 +3,10 @@ package execution
 import (
 	"context"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/tomwright/dasel/v3/model"
 )
@@ -55,6 +59,17 @@ var (
 		FuncFromEntries,
 		FuncToBool,
 		FuncStringify,
+		FuncApplyPatch,
+		FuncMergePatch,
+		FuncValidate,
+		FuncMatch,
+		FuncMatchAll,
+		FuncCapture,
+		FuncReplaceRegex,
+		FuncSplitRegex,
+		FuncPaths,
+		FuncGetPath,
+		FuncSetPath,
 	)
 )
 
@@ -101,27 +116,132 @@ func ValidateArgsMinMax(min int, max int) ArgsValidator {
 	}
 }
 
+// FuncParam describes a parameter of a function.
+type FuncParam struct {
+	Name string
+	// Type is the type of value expected, e.g. string, int, number, array, map or any.
+	Type string
+	// Optional is true if the parameter may be omitted.
+	// Functions that operate on the current value often accept it as an optional argument instead.
+	Optional bool
+	// Variadic is true if the parameter accepts any number of values.
+	Variadic bool
+}
+
+// String returns the parameter as written in a function signature, e.g. "values ...number".
+func (p FuncParam) String() string {
+	s := p.Name + " "
+	if p.Variadic {
+		s += "..."
+	}
+	s += p.Type
+	if p.Optional {
+		s = "[" + s + "]"
+	}
+	return s
+}
+
+// FuncExample is an example use of a function.
+type FuncExample struct {
+	// Selector is a selector that uses the function.
+	Selector string
+	// Output is a selector that evaluates to the result of Selector.
+	Output string
+}
+
+// FuncDoc describes a function for users.
+type FuncDoc struct {
+	Description string
+	Params      []FuncParam
+	// Returns is the type of value returned.
+	Returns  string
+	Examples []FuncExample
+}
+
 // Func represents a function that can be executed.
 type Func struct {
 	name          string
 	handler       FuncFn
 	argsValidator ArgsValidator
+	doc           FuncDoc
+}
+
+// Name returns the name the function is called by.
+func (f *Func) Name() string {
+	return f.name
+}
+
+// Doc returns the documentation of the function.
+func (f *Func) Doc() FuncDoc {
+	return f.doc
+}
+
+// WithDoc sets the documentation of the function and returns it.
+func (f *Func) WithDoc(doc FuncDoc) *Func {
+	f.doc = doc
+	return f
+}
+
+// Signature returns the name, parameters and return type of the function,
+// e.g. "join(separator string, values ...any) string".
+func (f *Func) Signature() string {
+	params := make([]string, len(f.doc.Params))
+	for i, p := range f.doc.Params {
+		params[i] = p.String()
+	}
+	s := f.name + "(" + strings.Join(params, ", ") + ")"
+	if f.doc.Returns != "" {
+		s += " " + f.doc.Returns
+	}
+	return s
 }
 
 // Handler returns a FuncFn that can be used to execute the function.
 func (f *Func) Handler() FuncFn {
-	return func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
-		if f.argsValidator != nil {
-			if err := f.argsValidator(ctx, f.name, args); err != nil {
-				return nil, err
-			}
-		}
-		res, err := f.handler(ctx, data, args)
-		if err != nil {
-			return nil, fmt.Errorf("error execution func %q: %w", f.name, err)
+	return f.call
+}
+
+// call validates the arguments and executes the function.
+func (f *Func) call(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+	if data == funcLookupValue {
+		return nil, funcLookup{f: f}
+	}
+	if f.argsValidator != nil {
+		if err := f.argsValidator(ctx, f.name, args); err != nil {
+			return nil, err
 		}
-		return res, nil
 	}
+	res, err := f.handler(ctx, data, args)
+	if err != nil {
+		return nil, fmt.Errorf("error execution func %q: %w", f.name, err)
+	}
+	return res, nil
+}
+
+// funcLookupValue is passed to a handler returned by Func.Handler to ask for its Func
+// rather than executing it. The Func is returned as a funcLookup error.
+var funcLookupValue = model.NewNullValue()
+
+// funcHandlerPointer is the code pointer shared by every handler returned by Func.Handler.
+var funcHandlerPointer = reflect.ValueOf((&Func{}).call).Pointer()
+
+type funcLookup struct {
+	f *Func
+}
+
+func (funcLookup) Error() string {
+	return "func lookup"
+}
+
+// funcOf returns the Func that fn is the handler of.
+// It returns false if fn was not returned by Func.Handler, in which case fn is not called.
+func funcOf(fn FuncFn) (*Func, bool) {
+	if fn == nil || reflect.ValueOf(fn).Pointer() != funcHandlerPointer {
+		return nil, false
+	}
+	_, err := fn(context.Background(), funcLookupValue, nil)
+	lookup, ok := err.(funcLookup)
+	return lookup.f, ok
 }
 
 // NewFunc creates a new Func.
@@ -158,6 +278,63 @@ func (fc FuncCollection) Get(name string) (FuncFn, bool) {
 	return fn, ok
 }
 
+// Func returns the function with the given name, along with its documentation.
+// The documentation and argument validator are read from the handler held by the collection,
+// so handlers that were not returned by Func.Handler have neither.
+func (fc FuncCollection) Func(name string) (*Func, bool) {
+	fn, ok := fc[name]
+	if !ok {
+		return nil, false
+	}
+	f, ok := funcOf(fn)
+	if !ok {
+		return &Func{name: name, handler: fn}, true
+	}
+	if f.name != name {
+		// The handler was added under a different name.
+		c := *f
+		c.name = name
+		return &c, true
+	}
+	return f, true
+}
+
+// CheckCall returns false if there is no function with the given name.
+// Otherwise it returns the error given by the function's ArgsValidator when passed the given number of arguments.
+// If args is negative the number of arguments is not known, and only the existence of the function is checked.
+func (fc FuncCollection) CheckCall(name string, args int) (bool, error) {
+	f, ok := fc.Func(name)
+	if !ok {
+		return false, nil
+	}
+	if f.argsValidator == nil || args < 0 {
+		return true, nil
+	}
+	values := make(model.Values, args)
+	for i := range values {
+		values[i] = model.NewNullValue()
+	}
+	return true, f.argsValidator(context.Background(), name, values)
+}
+
+// Suggest returns the name of the function most similar to name, or an empty string if none are similar enough.
+func (fc FuncCollection) Suggest(name string) string {
+	return suggestName(name, slices.Collect(maps.Keys(fc)))
+}
+
+// List returns the functions in the collection, sorted by name.
+func (fc FuncCollection) List() []*Func {
+	funcs := make([]*Func, 0, len(fc))
+	for name := range fc {
+		f, _ := fc.Func(name)
+		funcs = append(funcs, f)
+	}
+	slices.SortFunc(funcs, func(a, b *Func) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return funcs
+}
+
 // Delete deletes the functions with the given names.
 func (fc FuncCollection) Delete(names ...string) FuncCollection {
 	for _, name := range names {
@@ -37,4 +37,14 @@ var FuncAbs = NewFunc(
 		return model.NewFloatValue(math.Abs(v)), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the absolute value of a number.",
+	Params: []FuncParam{
+		{Name: "value", Type: "number", Optional: true},
+	},
+	Returns: "number",
+	Examples: []FuncExample{
+		{Selector: "abs(-5)", Output: "5"},
+		{Selector: "abs(-1.5)", Output: "1.5"},
+	},
+})
@@ -41,4 +41,14 @@ var FuncAdd = NewFunc(
 		return model.NewIntValue(intRes), nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Adds numbers together. The result is a float if any of the values are floats.",
+	Params: []FuncParam{
+		{Name: "values", Type: "number", Variadic: true},
+	},
+	Returns: "number",
+	Examples: []FuncExample{
+		{Selector: "add(1, 2, 3)", Output: "6"},
+		{Selector: "add(1, 2.5)", Output: "3.5"},
+	},
+})
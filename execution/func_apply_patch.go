@@ -0,0 +1,26 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncApplyPatch is a function that applies an RFC 6902 JSON Patch to the input.
+// If any operation fails, the input is left unmodified.
+var FuncApplyPatch = NewFunc(
+	"applyPatch",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		return ApplyJSONPatch(data, args[0])
+	},
+	ValidateArgsExactly(1),
+).WithDoc(FuncDoc{
+	Description: "Applies an RFC 6902 JSON Patch to the current value. If any operation fails, the value is left unmodified.",
+	Params: []FuncParam{
+		{Name: "patch", Type: "array"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.applyPatch([{"op": "add", "path": "/b", "value": 2}])`, Output: `{"a": 1, "b": 2}`},
+	},
+})
@@ -0,0 +1,103 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncApplyPatch(t *testing.T) {
+	t.Run("add map key", testCase{
+		s: `{"a":1}.applyPatch([{"op":"add","path":"/b","value":2}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", int64(1)).Set("b", int64(2)))
+		},
+	}.run)
+	t.Run("add slice index", testCase{
+		s:   `[1,3].applyPatch([{"op":"add","path":"/1","value":2}])`,
+		out: model.NewValue([]any{int64(1), int64(2), int64(3)}),
+	}.run)
+	t.Run("append to slice", testCase{
+		s:   `[1,2].applyPatch([{"op":"add","path":"/-","value":3}])`,
+		out: model.NewValue([]any{int64(1), int64(2), int64(3)}),
+	}.run)
+	t.Run("remove", testCase{
+		s: `{"a":[1,2,3],"b":1}.applyPatch([{"op":"remove","path":"/a/1"},{"op":"remove","path":"/b"}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", []any{int64(1), int64(3)}))
+		},
+	}.run)
+	t.Run("replace", testCase{
+		s: `{"a":{"b":1}}.applyPatch([{"op":"replace","path":"/a/b","value":"x"}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", orderedmap.NewMap().Set("b", "x")))
+		},
+	}.run)
+	t.Run("move", testCase{
+		s: `{"a":{"b":1},"c":{}}.applyPatch([{"op":"move","from":"/a/b","path":"/c/d"}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("a", orderedmap.NewMap()).
+				Set("c", orderedmap.NewMap().Set("d", int64(1))))
+		},
+	}.run)
+	t.Run("copy", testCase{
+		s: `{"a":[1]}.applyPatch([{"op":"copy","from":"/a","path":"/b"}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("a", []any{int64(1)}).
+				Set("b", []any{int64(1)}))
+		},
+	}.run)
+	t.Run("escaped pointer", testCase{
+		s: `{"a/b":1,"c~d":2}.applyPatch([{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/c~0d"}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a/b", int64(3)))
+		},
+	}.run)
+	t.Run("test passes", testCase{
+		s: `{"a":1}.applyPatch([{"op":"test","path":"/a","value":1},{"op":"add","path":"/b","value":2}])`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", int64(1)).Set("b", int64(2)))
+		},
+	}.run)
+
+	t.Run("failed operation is atomic", func(t *testing.T) {
+		errorCases := map[string]string{
+			"test fails":          `[{"op":"add","path":"/b","value":2},{"op":"test","path":"/a","value":2}]`,
+			"missing path":        `[{"op":"add","path":"/b","value":2},{"op":"remove","path":"/x"}]`,
+			"index out of range":  `[{"op":"add","path":"/b","value":2},{"op":"add","path":"/c/5","value":1}]`,
+			"leading zero index":  `[{"op":"add","path":"/b","value":2},{"op":"replace","path":"/c/01","value":1}]`,
+			"unknown operation":   `[{"op":"add","path":"/b","value":2},{"op":"nope","path":"/a"}]`,
+			"move into own child": `[{"op":"add","path":"/b","value":2},{"op":"move","from":"/c","path":"/c/0"}]`,
+		}
+		for name, patch := range errorCases {
+			t.Run(name, func(t *testing.T) {
+				in := model.NewValue(orderedmap.NewMap().Set("a", int64(1)).Set("c", []any{int64(1)}))
+				before := in.String()
+				_, err := execution.ExecuteSelector(context.Background(), `applyPatch(`+patch+`)`, in, execution.NewOptions())
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if in.String() != before {
+					t.Errorf("input was modified:\nexp: %s\ngot: %s", before, in.String())
+				}
+			})
+		}
+	})
+
+	t.Run("test failure error", func(t *testing.T) {
+		doc := model.NewValue(orderedmap.NewMap().Set("a", int64(1)))
+		patch := model.NewValue([]any{
+			orderedmap.NewMap().Set("op", "test").Set("path", "/a").Set("value", "1"),
+		})
+		_, err := execution.ApplyJSONPatch(doc, patch)
+		if !errors.Is(err, execution.ErrPatchTestFailed) {
+			t.Errorf("expected ErrPatchTestFailed, got %v", err)
+		}
+	})
+}
@@ -37,4 +37,13 @@ var FuncAvg = NewFunc(
 		return model.NewFloatValue(sum / float64(len(args))), nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the average of the given numbers as a float.",
+	Params: []FuncParam{
+		{Name: "values", Type: "number", Variadic: true},
+	},
+	Returns: "float",
+	Examples: []FuncExample{
+		{Selector: "avg(1, 2, 3, 4)", Output: "2.5"},
+	},
+})
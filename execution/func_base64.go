@@ -20,7 +20,16 @@ var FuncBase64Encode = NewFunc(
 		return model.NewStringValue(out), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Base64 encodes a string.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `base64e("hello")`, Output: `"aGVsbG8="`},
+	},
+})
 
 // FuncBase64Decode base64 decodes the given value.
 var FuncBase64Decode = NewFunc(
@@ -38,4 +47,13 @@ var FuncBase64Decode = NewFunc(
 		return model.NewStringValue(string(out)), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Decodes a base64 encoded string.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `base64d("aGVsbG8=")`, Output: `"hello"`},
+	},
+})
@@ -0,0 +1,48 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncCapture is a function that returns the named capture groups of the first regex match within a string.
+// The result is a map of group name to value, or null if there is no match.
+var FuncCapture = NewFunc(
+	"capture",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		s, re, _, err := regexFuncArgs(data, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		loc := re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			return model.NewNullValue(), nil
+		}
+		res := model.NewMapValue()
+		for i, name := range re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			group := model.NewNullValue()
+			if loc[2*i] >= 0 {
+				group = model.NewStringValue(s[loc[2*i]:loc[2*i+1]])
+			}
+			if err := res.SetMapKey(name, group); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	},
+	ValidateArgsMinMax(1, 2),
+).WithDoc(FuncDoc{
+	Description: "Returns the named capture groups of the first match of a regular expression as a map, or null if there is no match.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "pattern", Type: "regex"},
+	},
+	Returns: "map",
+	Examples: []FuncExample{
+		{Selector: `capture("v1.2", r/v(?P<major>\d+)\.(?P<minor>\d+)/)`, Output: `{"major": "1", "minor": "2"}`},
+	},
+})
@@ -0,0 +1,30 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncCapture(t *testing.T) {
+	t.Run("named groups", testCase{
+		s: `"2024-01-02 ERROR disk full".capture(r/^(?P<date>\S+) (?P<level>\w+) (?P<msg>.*)$/)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("date", "2024-01-02").
+			Set("level", "ERROR").
+			Set("msg", "disk full")),
+	}.run)
+	t.Run("select group", testCase{
+		s:   `capture("v1.22.3", r/v(?P<major>\d+)\.(?P<minor>\d+)/).minor`,
+		out: model.NewStringValue("22"),
+	}.run)
+	t.Run("unnamed groups are ignored", testCase{
+		s:   `capture("ab", r/(a)(?P<b>b)/)`,
+		out: model.NewValue(orderedmap.NewMap().Set("b", "b")),
+	}.run)
+	t.Run("no match", testCase{
+		s:   `capture("abc", r/(?P<n>\d)/)`,
+		out: model.NewNullValue(),
+	}.run)
+}
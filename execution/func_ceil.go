@@ -34,4 +34,13 @@ var FuncCeil = NewFunc(
 		return model.NewIntValue(int64(math.Ceil(v))), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Rounds a number up to the nearest integer.",
+	Params: []FuncParam{
+		{Name: "value", Type: "number", Optional: true},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: "ceil(1.2)", Output: "2"},
+	},
+})
@@ -41,4 +41,13 @@ var FuncContains = NewFunc(
 		return model.NewBoolValue(contains), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns true if the current array contains the given value.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "bool",
+	Examples: []FuncExample{
+		{Selector: "[1, 2, 3].contains(2)", Output: "true"},
+	},
+})
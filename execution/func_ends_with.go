@@ -39,4 +39,15 @@ var FuncEndsWith = NewFunc(
 		return model.NewBoolValue(strings.HasSuffix(input, suffix)), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns true if a string ends with the given suffix.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "suffix", Type: "string"},
+	},
+	Returns: "bool",
+	Examples: []FuncExample{
+		{Selector: `endsWith("config.yaml", ".yaml")`, Output: "true"},
+		{Selector: `"config.yaml".endsWith(".json")`, Output: "false"},
+	},
+})
@@ -32,7 +32,13 @@ var FuncEntries = NewFunc(
 		return res, nil
 	},
 	ValidateArgsExactly(0),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts the current map into an array of {key, value} maps.",
+	Returns:     "array",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.entries()`, Output: `[{"key": "a", "value": 1}]`},
+	},
+})
 
 // FuncFromEntries converts an array of {key, value} objects into a map.
 var FuncFromEntries = NewFunc(
@@ -77,4 +83,13 @@ var FuncFromEntries = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts an array of {key, value} maps into a map.",
+	Params: []FuncParam{
+		{Name: "entries", Type: "array", Optional: true},
+	},
+	Returns: "map",
+	Examples: []FuncExample{
+		{Selector: `fromEntries([{"key": "a", "value": 1}])`, Output: `{"a": 1}`},
+	},
+})
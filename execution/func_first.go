@@ -33,4 +33,13 @@ var FuncFirst = NewFunc(
 		return input.GetSliceIndex(0)
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the first element of an array, or null if it is empty.",
+	Params: []FuncParam{
+		{Name: "array", Type: "array", Optional: true},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: "first([1, 2, 3])", Output: "1"},
+	},
+})
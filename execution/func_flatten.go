@@ -37,4 +37,13 @@ var FuncFlatten = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Flattens nested arrays by one level.",
+	Params: []FuncParam{
+		{Name: "array", Type: "array", Optional: true},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: "flatten([1, [2, [3]]])", Output: "[1, 2, [3]]"},
+	},
+})
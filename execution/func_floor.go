@@ -34,4 +34,13 @@ var FuncFloor = NewFunc(
 		return model.NewIntValue(int64(math.Floor(v))), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Rounds a number down to the nearest integer.",
+	Params: []FuncParam{
+		{Name: "value", Type: "number", Optional: true},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: "floor(1.8)", Output: "1"},
+	},
+})
@@ -32,4 +32,14 @@ var FuncGet = NewFunc(
 		}
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the value at the given map key or array index of the current value.",
+	Params: []FuncParam{
+		{Name: "key", Type: "string|int"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.get("a")`, Output: "1"},
+		{Selector: "[1, 2, 3].get(1)", Output: "2"},
+	},
+})
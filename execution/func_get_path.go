@@ -0,0 +1,38 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncGetPath is a function that returns the value at the given path.
+// The path is an array of map keys and slice indexes, as returned by paths() and $path.
+var FuncGetPath = NewFunc(
+	"getPath",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		path, err := pathFromValue(args[0])
+		if err != nil {
+			return nil, err
+		}
+		cur := data
+		for _, segment := range path {
+			cur, err = getPathSegment(cur, segment)
+			if err != nil {
+				return nil, fmt.Errorf("error getting path %v: %w", path, err)
+			}
+		}
+		return cur, nil
+	},
+	ValidateArgsExactly(1),
+).WithDoc(FuncDoc{
+	Description: "Returns the value at the given path within the current value. The path is an array of map keys and array indexes, as returned by paths() and $path.",
+	Params: []FuncParam{
+		{Name: "path", Type: "array"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": [1, 2]}.getPath(["a", 1])`, Output: "2"},
+	},
+})
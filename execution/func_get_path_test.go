@@ -0,0 +1,33 @@
+package execution_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncGetPath(t *testing.T) {
+	in := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("spec", orderedmap.NewMap().
+				Set("ports", []any{int64(80), int64(443)})))
+	}
+	t.Run("nested", testCase{
+		inFn: in,
+		s:    `getPath(["spec", "ports", 1])`,
+		out:  model.NewIntValue(443),
+	}.run)
+	t.Run("empty path", testCase{
+		s:   `1.getPath([])`,
+		out: model.NewIntValue(1),
+	}.run)
+	t.Run("missing", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `getPath(["spec", "missing"])`, in(), execution.NewOptions())
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
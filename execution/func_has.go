@@ -47,4 +47,14 @@ var FuncHas = NewFunc(
 		}
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns true if the current value has the given map key or array index.",
+	Params: []FuncParam{
+		{Name: "key", Type: "string|int"},
+	},
+	Returns: "bool",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.has("a")`, Output: "true"},
+		{Selector: "[1, 2].has(2)", Output: "false"},
+	},
+})
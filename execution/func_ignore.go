@@ -13,4 +13,7 @@ var FuncIgnore = NewFunc(
 		return data, nil
 	},
 	ValidateArgsExactly(0),
-)
+).WithDoc(FuncDoc{
+	Description: "Marks the current value as ignored, removing it from the results of a branch. Requires unstable features to be enabled.",
+	Returns:     "any",
+})
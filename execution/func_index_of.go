@@ -40,4 +40,14 @@ var FuncIndexOf = NewFunc(
 		return model.NewIntValue(int64(strings.Index(input, substr))), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the index of the first occurrence of a substring, or -1 if it is not found.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "substring", Type: "string"},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: `indexOf("hello", "l")`, Output: "2"},
+	},
+})
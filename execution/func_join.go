@@ -57,4 +57,15 @@ var FuncJoin = NewFunc(
 		return model.NewStringValue(joined), nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Joins strings with a separator. The strings may be given as arguments or as an array, and default to the current array.",
+	Params: []FuncParam{
+		{Name: "separator", Type: "string"},
+		{Name: "values", Type: "any", Variadic: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `join(", ", "a", "b")`, Output: `"a, b"`},
+		{Selector: `["a", "b"].join("-")`, Output: `"a-b"`},
+	},
+})
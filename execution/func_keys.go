@@ -41,4 +41,10 @@ var FuncKeys = NewFunc(
 		}
 	},
 	ValidateArgsExactly(0),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the keys of the current map, or the indexes of the current array.",
+	Returns:     "array",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1, "b": 2}.keys()`, Output: `["a", "b"]`},
+	},
+})
@@ -33,4 +33,13 @@ var FuncLast = NewFunc(
 		return input.GetSliceIndex(length - 1)
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the last element of an array, or null if it is empty.",
+	Params: []FuncParam{
+		{Name: "array", Type: "array", Optional: true},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: "last([1, 2, 3])", Output: "3"},
+	},
+})
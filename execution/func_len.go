@@ -19,4 +19,14 @@ var FuncLen = NewFunc(
 		return model.NewIntValue(int64(l)), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the length of a string, array or map.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: "len([1, 2, 3])", Output: "3"},
+		{Selector: `len("hello")`, Output: "5"},
+	},
+})
@@ -0,0 +1,36 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncMatch is a function that returns the first regex match within a string.
+// The result is an array containing the full match followed by each capture group,
+// or null if there is no match.
+var FuncMatch = NewFunc(
+	"match",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		s, re, _, err := regexFuncArgs(data, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		loc := re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			return model.NewNullValue(), nil
+		}
+		return regexSubmatchValue(s, loc)
+	},
+	ValidateArgsMinMax(1, 2),
+).WithDoc(FuncDoc{
+	Description: "Returns the first match of a regular expression as an array containing the full match followed by each capture group, or null if there is no match.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "pattern", Type: "regex"},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: `match("a1b2", r/[a-z](\d)/)`, Output: `["a1", "1"]`},
+	},
+})
@@ -0,0 +1,41 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncMatchAll is a function that returns every regex match within a string.
+// Each match is an array containing the full match followed by each capture group.
+var FuncMatchAll = NewFunc(
+	"matchAll",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		s, re, _, err := regexFuncArgs(data, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		res := model.NewSliceValue()
+		for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+			match, err := regexSubmatchValue(s, loc)
+			if err != nil {
+				return nil, err
+			}
+			if err := res.Append(match); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	},
+	ValidateArgsMinMax(1, 2),
+).WithDoc(FuncDoc{
+	Description: "Returns every match of a regular expression. Each match is an array containing the full match followed by each capture group.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "pattern", Type: "regex"},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: `matchAll("a1b2", r/[a-z](\d)/)`, Output: `[["a1", "1"], ["b2", "2"]]`},
+	},
+})
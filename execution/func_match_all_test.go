@@ -0,0 +1,25 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestFuncMatchAll(t *testing.T) {
+	t.Run("groups", testCase{
+		s: `"a=1 b=2".matchAll(r/(\w)=(\d)/)`,
+		out: model.NewValue([]any{
+			[]any{"a=1", "a", "1"},
+			[]any{"b=2", "b", "2"},
+		}),
+	}.run)
+	t.Run("arg input", testCase{
+		s:   `matchAll("1 2 3", r/\d/)`,
+		out: model.NewValue([]any{[]any{"1"}, []any{"2"}, []any{"3"}}),
+	}.run)
+	t.Run("no match", testCase{
+		s:   `matchAll("abc", r/\d/)`,
+		out: model.NewSliceValue(),
+	}.run)
+}
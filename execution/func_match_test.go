@@ -0,0 +1,38 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestFuncMatch(t *testing.T) {
+	t.Run("chained input", testCase{
+		s:   `"v1.22.3".match(r/v(\d+)\.(\d+)/)`,
+		out: model.NewValue([]any{"v1.22", "1", "22"}),
+	}.run)
+	t.Run("arg input", testCase{
+		s:   `match("v1.22.3", r/v(\d+)\.(\d+)/)`,
+		out: model.NewValue([]any{"v1.22", "1", "22"}),
+	}.run)
+	t.Run("string pattern", testCase{
+		s:   `match("abc", "b+")`,
+		out: model.NewValue([]any{"b"}),
+	}.run)
+	t.Run("unmatched group", testCase{
+		s: `match("a", r/(a)|(b)/)`,
+		outFn: func() *model.Value {
+			r := model.NewSliceValue()
+			for _, v := range []*model.Value{model.NewStringValue("a"), model.NewStringValue("a"), model.NewNullValue()} {
+				if err := r.Append(v); err != nil {
+					panic(err)
+				}
+			}
+			return r
+		},
+	}.run)
+	t.Run("no match", testCase{
+		s:   `match("abc", r/\d/)`,
+		out: model.NewNullValue(),
+	}.run)
+}
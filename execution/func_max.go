@@ -30,4 +30,13 @@ var FuncMax = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the largest of the given values.",
+	Params: []FuncParam{
+		{Name: "values", Type: "any", Variadic: true},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: "max(1, 5, 3)", Output: "5"},
+	},
+})
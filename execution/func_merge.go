@@ -67,4 +67,13 @@ var FuncMerge = NewFunc(
 		return base, nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Deep merges maps together. Values in later maps take precedence.",
+	Params: []FuncParam{
+		{Name: "maps", Type: "map", Variadic: true},
+	},
+	Returns: "map",
+	Examples: []FuncExample{
+		{Selector: `merge({"a": {"b": 1}}, {"a": {"c": 2}})`, Output: `{"a": {"b": 1, "c": 2}}`},
+	},
+})
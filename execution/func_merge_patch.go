@@ -0,0 +1,25 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncMergePatch is a function that applies an RFC 7386 JSON Merge Patch to the input.
+var FuncMergePatch = NewFunc(
+	"mergePatch",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		return ApplyMergePatch(data, args[0])
+	},
+	ValidateArgsExactly(1),
+).WithDoc(FuncDoc{
+	Description: "Applies an RFC 7386 JSON Merge Patch to the current value.",
+	Params: []FuncParam{
+		{Name: "patch", Type: "map"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1, "b": 2}.mergePatch({"b": null})`, Output: `{"a": 1}`},
+	},
+})
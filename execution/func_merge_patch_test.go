@@ -0,0 +1,49 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncMergePatch(t *testing.T) {
+	t.Run("merge nested maps", testCase{
+		s: `{"a":"b","c":{"d":"e","f":"g"}}.mergePatch({"a":"z","c":{"f":null}})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("a", "z").
+				Set("c", orderedmap.NewMap().Set("d", "e")))
+		},
+	}.run)
+	t.Run("replace slice", testCase{
+		s: `{"a":[1,2]}.mergePatch({"a":[3]})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", []any{int64(3)}))
+		},
+	}.run)
+	t.Run("replace scalar with map", testCase{
+		s: `{"a":"foo"}.mergePatch({"a":{"b":"c"}})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", orderedmap.NewMap().Set("b", "c")))
+		},
+	}.run)
+	t.Run("delete missing key", testCase{
+		s: `{"a":1}.mergePatch({"b":null})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", int64(1)))
+		},
+	}.run)
+	t.Run("non map patch replaces document", testCase{
+		s:   `{"a":1}.mergePatch(["x"])`,
+		out: model.NewValue([]any{"x"}),
+	}.run)
+	t.Run("input is not modified", testCase{
+		in: model.NewValue(orderedmap.NewMap().Set("a", int64(1))),
+		s:  `mergePatch({"a":null,"b":2})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("a", int64(1)))
+		},
+		compareRoot: true,
+	}.run)
+}
@@ -30,4 +30,13 @@ var FuncMin = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the smallest of the given values.",
+	Params: []FuncParam{
+		{Name: "values", Type: "any", Variadic: true},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: "min(4, 2, 3)", Output: "2"},
+	},
+})
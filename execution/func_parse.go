@@ -40,4 +40,14 @@ var FuncParse = NewFunc(
 		return doc, nil
 	},
 	ValidateArgsExactly(2),
-)
+).WithDoc(FuncDoc{
+	Description: "Parses a string in the given format, e.g. json or yaml.",
+	Params: []FuncParam{
+		{Name: "format", Type: "string"},
+		{Name: "content", Type: "string"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `parse("json", "{\"a\": 1}")`, Output: `{"a": 1}`},
+	},
+})
@@ -0,0 +1,62 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncPaths is a function that returns the path to every leaf value as an array of map keys and slice indexes.
+// Leaves are scalar values and empty maps or arrays.
+var FuncPaths = NewFunc(
+	"paths",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		input := data
+		if len(args) == 1 {
+			input = args[0]
+		}
+
+		res := model.NewSliceValue()
+		var walk func(path []any, v *model.Value) error
+		walk = func(path []any, v *model.Value) error {
+			child := func(segment any, item *model.Value) error {
+				return walk(append(path[:len(path):len(path)], segment), item)
+			}
+			if v.IsMap() || v.IsSlice() {
+				l, err := v.Len()
+				if err != nil {
+					return err
+				}
+				if l > 0 && v.IsMap() {
+					return v.RangeMap(func(key string, item *model.Value) error {
+						return child(key, item)
+					})
+				}
+				if l > 0 {
+					return v.RangeSlice(func(i int, item *model.Value) error {
+						return child(i, item)
+					})
+				}
+			}
+			p, err := pathValue(path)
+			if err != nil {
+				return err
+			}
+			return res.Append(p)
+		}
+		if err := walk(nil, input); err != nil {
+			return nil, err
+		}
+		return res, nil
+	},
+	ValidateArgsMax(1),
+).WithDoc(FuncDoc{
+	Description: "Returns the path to every leaf value as an array of map keys and array indexes. Leaves are scalar values and empty maps or arrays.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any", Optional: true},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: `paths({"a": [1, 2]})`, Output: `[["a", 0], ["a", 1]]`},
+	},
+})
@@ -0,0 +1,34 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncPaths(t *testing.T) {
+	t.Run("leaves", testCase{
+		inFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().
+				Set("name", "api").
+				Set("ports", []any{int64(80), int64(443)}).
+				Set("labels", orderedmap.NewMap()))
+		},
+		s: `paths()`,
+		out: model.NewValue([]any{
+			[]any{"name"},
+			[]any{"ports", int64(0)},
+			[]any{"ports", int64(1)},
+			[]any{"labels"},
+		}),
+	}.run)
+	t.Run("arg input", testCase{
+		s:   `paths({"a": {"b": 1}})`,
+		out: model.NewValue([]any{[]any{"a", "b"}}),
+	}.run)
+	t.Run("scalar", testCase{
+		s:   `paths(1)`,
+		out: model.NewValue([]any{[]any{}}),
+	}.run)
+}
@@ -33,4 +33,10 @@ var FuncReadFile = NewFunc(
 		return model.NewStringValue(string(fileBytes)), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Reads the contents of the file at the given path.",
+	Params: []FuncParam{
+		{Name: "path", Type: "string"},
+	},
+	Returns: "string",
+})
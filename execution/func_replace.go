@@ -37,4 +37,15 @@ var FuncReplace = NewFunc(
 		return model.NewStringValue(outputString), nil
 	},
 	ValidateArgsMin(2),
-)
+).WithDoc(FuncDoc{
+	Description: "Replaces every occurrence of each old string with its new string. Any number of old, new pairs may be given.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "replacements", Type: "string", Variadic: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `replace("hello", "l", "L")`, Output: `"heLLo"`},
+		{Selector: `"a-b_c".replace("-", ".", "_", ".")`, Output: `"a.b.c"`},
+	},
+})
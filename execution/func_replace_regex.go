@@ -0,0 +1,38 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncReplaceRegex is a function that replaces all regex matches within a string.
+// The replacement may reference capture groups with $1 or $name.
+var FuncReplaceRegex = NewFunc(
+	"replaceRegex",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		s, re, args, err := regexFuncArgs(data, args, 2)
+		if err != nil {
+			return nil, err
+		}
+		replacement, err := args[0].StringValue()
+		if err != nil {
+			return nil, fmt.Errorf("expected replacement to be a string: %w", err)
+		}
+		return model.NewStringValue(re.ReplaceAllString(s, replacement)), nil
+	},
+	ValidateArgsMinMax(2, 3),
+).WithDoc(FuncDoc{
+	Description: "Replaces every match of a regular expression. The replacement may reference capture groups with $1, $name or ${name}. Write ${name} as \\${name} within a double quoted string, since ${...} is interpolated there.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "pattern", Type: "regex"},
+		{Name: "replacement", Type: "string"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `replaceRegex("a1b2", r/\d/, "#")`, Output: `"a#b#"`},
+		{Selector: `replaceRegex("v1.2", r/v(?P<major>\d+)\.(?P<minor>\d+)/, "\${minor}_\${major}")`, Output: `"2_1"`},
+	},
+})
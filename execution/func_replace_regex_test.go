@@ -0,0 +1,34 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestFuncReplaceRegex(t *testing.T) {
+	t.Run("chained input", testCase{
+		s:   `"a1b22c333".replaceRegex(r/\d+/, "-")`,
+		out: model.NewStringValue("a-b-c-"),
+	}.run)
+	t.Run("arg input with backreferences", testCase{
+		s:   `replaceRegex("John Smith", r/(\w+) (\w+)/, "$2, $1")`,
+		out: model.NewStringValue("Smith, John"),
+	}.run)
+	t.Run("named backreferences", testCase{
+		s:   `replaceRegex("v1.2", r/v(?P<major>\d+)\.\d+/, "major=$major")`,
+		out: model.NewStringValue("major=1"),
+	}.run)
+	t.Run("braced named backreferences", testCase{
+		s:   `replaceRegex("v1.2", r/v(?P<major>\d+)\.(?P<minor>\d+)/, "\${minor}_\${major}")`,
+		out: model.NewStringValue("2_1"),
+	}.run)
+	t.Run("braced named backreferences in single quotes", testCase{
+		s:   `replaceRegex("v1.2", r/v(?P<major>\d+)\.(?P<minor>\d+)/, '${minor}_${major}')`,
+		out: model.NewStringValue("2_1"),
+	}.run)
+	t.Run("no match", testCase{
+		s:   `replaceRegex("abc", r/\d/, "x")`,
+		out: model.NewStringValue("abc"),
+	}.run)
+}
@@ -23,4 +23,14 @@ var FuncReverse = NewFunc(
 		}
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Reverses a string or array.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string|array"},
+	},
+	Returns: "string|array",
+	Examples: []FuncExample{
+		{Selector: "reverse([1, 2, 3])", Output: "[3, 2, 1]"},
+		{Selector: `reverse("abc")`, Output: `"cba"`},
+	},
+})
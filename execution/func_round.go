@@ -34,4 +34,13 @@ var FuncRound = NewFunc(
 		return model.NewIntValue(int64(math.Round(v))), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Rounds a number to the nearest integer.",
+	Params: []FuncParam{
+		{Name: "value", Type: "number", Optional: true},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: "round(1.5)", Output: "2"},
+	},
+})
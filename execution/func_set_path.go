@@ -0,0 +1,116 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncSetPath is a function that sets the value at the given path and returns a modified copy of the input.
+// The path is an array of map keys and slice indexes, as returned by paths() and $path.
+// Missing or null maps and arrays along the path are created, and an index equal to the length of an array appends to it.
+// Only the maps and arrays along the path are copied, and a path that passes through any other value is an error.
+var FuncSetPath = NewFunc(
+	"setPath",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		path, err := pathFromValue(args[0])
+		if err != nil {
+			return nil, err
+		}
+		res, err := setPath(data, path, 0, args[1])
+		if err != nil {
+			return nil, fmt.Errorf("error setting path %v: %w", path, err)
+		}
+		return res, nil
+	},
+	ValidateArgsExactly(2),
+).WithDoc(FuncDoc{
+	Description: "Sets the value at the given path and returns a modified copy of the current value. Missing maps and arrays along the path are created.",
+	Params: []FuncParam{
+		{Name: "path", Type: "array"},
+		{Name: "value", Type: "any"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.setPath(["b", "c"], 2)`, Output: `{"a": 1, "b": {"c": 2}}`},
+	},
+})
+
+// setPath returns a copy of data with value set at path[i:].
+// data may be nil if it does not exist yet.
+func setPath(data *model.Value, path []any, i int, value *model.Value) (*model.Value, error) {
+	if i == len(path) {
+		return value, nil
+	}
+
+	segment := path[i]
+	_, isIndex := segment.(int)
+	var res *model.Value
+	switch {
+	case data == nil || data.IsNull():
+		res = model.NewMapValue()
+		if isIndex {
+			res = model.NewSliceValue()
+		}
+	case data.IsMap() && !isIndex:
+		res = model.NewMapValue()
+		if err := data.RangeMap(func(key string, v *model.Value) error {
+			return res.SetMapKey(key, v)
+		}); err != nil {
+			return nil, err
+		}
+	case data.IsSlice() && isIndex:
+		res = model.NewSliceValue()
+		if err := data.RangeSlice(func(_ int, v *model.Value) error {
+			return res.Append(v)
+		}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot set %v on %s at %v", segment, data.Type(), path[:i])
+	}
+	if data != nil {
+		res.Metadata = maps.Clone(data.Metadata)
+	}
+
+	child, err := getPathSegment(res, segment)
+	if err != nil {
+		child = nil
+	}
+	child, err = setPath(child, path, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := setPathSegment(res, segment, child); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func getPathSegment(parent *model.Value, segment any) (*model.Value, error) {
+	switch s := segment.(type) {
+	case string:
+		return parent.GetMapKey(s)
+	default:
+		return parent.GetSliceIndex(s.(int))
+	}
+}
+
+func setPathSegment(parent *model.Value, segment any, value *model.Value) error {
+	switch s := segment.(type) {
+	case string:
+		return parent.SetMapKey(s, value)
+	default:
+		index := s.(int)
+		l, err := parent.SliceLen()
+		if err != nil {
+			return err
+		}
+		if index == l {
+			return parent.Append(value)
+		}
+		return parent.SetSliceIndex(index, value)
+	}
+}
@@ -0,0 +1,81 @@
+package execution_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+func TestFuncSetPath(t *testing.T) {
+	in := func() *model.Value {
+		return model.NewValue(orderedmap.NewMap().
+			Set("spec", orderedmap.NewMap().
+				Set("ports", []any{int64(80), int64(443)})))
+	}
+	t.Run("existing", testCase{
+		inFn: in,
+		s:    `setPath(["spec", "ports", 1], 8443)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("spec", orderedmap.NewMap().
+				Set("ports", []any{int64(80), int64(8443)}))),
+	}.run)
+	t.Run("append", testCase{
+		inFn: in,
+		s:    `setPath(["spec", "ports", 2], 9000)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("spec", orderedmap.NewMap().
+				Set("ports", []any{int64(80), int64(443), int64(9000)}))),
+	}.run)
+	t.Run("creates missing", testCase{
+		s: `{}.setPath(["a", "b", 0, "c"], true)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("a", orderedmap.NewMap().
+				Set("b", []any{orderedmap.NewMap().Set("c", true)}))),
+	}.run)
+	t.Run("empty path", testCase{
+		inFn: in,
+		s:    `setPath([], 1)`,
+		out:  model.NewIntValue(1),
+	}.run)
+	t.Run("new key", testCase{
+		inFn: in,
+		s:    `setPath(["spec", "replicas"], 3)`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("spec", orderedmap.NewMap().
+				Set("ports", []any{int64(80), int64(443)}).
+				Set("replicas", int64(3)))),
+	}.run)
+	t.Run("does not modify input", testCase{
+		inFn:        in,
+		s:           `setPath(["spec", "ports", 0], 8080)`,
+		compareRoot: true,
+		outFn:       in,
+	}.run)
+	t.Run("does not copy other values", func(t *testing.T) {
+		input := in()
+		other := model.NewValue([]any{"x"})
+		if err := input.SetMapKey("other", other); err != nil {
+			t.Fatal(err)
+		}
+		res, err := execution.ExecuteSelector(t.Context(), `setPath(["spec", "replicas"], 3)`, input, execution.NewOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := res.GetMapKey("other")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != other {
+			t.Errorf("expected values outside the path to be shared with the input")
+		}
+	})
+	t.Run("scalar along path", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(t.Context(), `setPath(["spec", "ports", 0, "number"], 1)`, in(), execution.NewOptions())
+		if err == nil || !strings.Contains(err.Error(), "cannot set number on int at [spec ports 0]") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
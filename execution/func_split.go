@@ -42,4 +42,15 @@ var FuncSplit = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Splits a string into an array around each occurrence of the separator.",
+	Params: []FuncParam{
+		{Name: "separator", Type: "string"},
+		{Name: "value", Type: "string", Optional: true},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: `split(",", "a,b")`, Output: `["a", "b"]`},
+		{Selector: `"a.b".split(".")`, Output: `["a", "b"]`},
+	},
+})
@@ -0,0 +1,36 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncSplitRegex is a function that splits a string into an array around each regex match.
+var FuncSplitRegex = NewFunc(
+	"splitRegex",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		s, re, _, err := regexFuncArgs(data, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		res := model.NewSliceValue()
+		for _, part := range re.Split(s, -1) {
+			if err := res.Append(model.NewStringValue(part)); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	},
+	ValidateArgsMinMax(1, 2),
+).WithDoc(FuncDoc{
+	Description: "Splits a string into an array around each match of a regular expression.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "pattern", Type: "regex"},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: `splitRegex("a1b22c", r/\d+/)`, Output: `["a", "b", "c"]`},
+	},
+})
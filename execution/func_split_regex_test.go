@@ -0,0 +1,22 @@
+package execution_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestFuncSplitRegex(t *testing.T) {
+	t.Run("chained input", testCase{
+		s:   `"a, b;c".splitRegex(r/[,;]\s*/)`,
+		out: model.NewValue([]any{"a", "b", "c"}),
+	}.run)
+	t.Run("arg input", testCase{
+		s:   `splitRegex("a1b22c", r/\d+/)`,
+		out: model.NewValue([]any{"a", "b", "c"}),
+	}.run)
+	t.Run("no match", testCase{
+		s:   `splitRegex("abc", r/\d/)`,
+		out: model.NewValue([]any{"abc"}),
+	}.run)
+}
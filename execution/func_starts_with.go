@@ -39,4 +39,14 @@ var FuncStartsWith = NewFunc(
 		return model.NewBoolValue(strings.HasPrefix(input, prefix)), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns true if a string starts with the given prefix.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "prefix", Type: "string"},
+	},
+	Returns: "bool",
+	Examples: []FuncExample{
+		{Selector: `startsWith("v1.2", "v")`, Output: "true"},
+	},
+})
@@ -39,4 +39,14 @@ var FuncStringify = NewFunc(
 		return model.NewStringValue(string(b)), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Serialises a value in the given format, e.g. json or yaml, using compact output.",
+	Params: []FuncParam{
+		{Name: "format", Type: "string"},
+		{Name: "value", Type: "any", Optional: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `stringify("json", {"a": 1})`, Output: `"{\"a\":1}"`},
+	},
+})
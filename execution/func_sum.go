@@ -68,4 +68,13 @@ var FuncSum = NewFunc(
 		}
 	},
 	ValidateArgsMin(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the sum of the given numbers. The result is a float if any of the values are floats.",
+	Params: []FuncParam{
+		{Name: "values", Type: "number", Variadic: true},
+	},
+	Returns: "number",
+	Examples: []FuncExample{
+		{Selector: "sum(1, 2, 3)", Output: "6"},
+	},
+})
@@ -51,4 +51,14 @@ var FuncToBool = NewFunc(
 		}
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a value to a bool. Strings such as \"yes\", \"1\" and \"false\" are accepted.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "bool",
+	Examples: []FuncExample{
+		{Selector: `toBool("yes")`, Output: "true"},
+		{Selector: "toBool(0)", Output: "false"},
+	},
+})
@@ -47,4 +47,13 @@ var FuncToFloat = NewFunc(
 		}
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a value to a float.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "float",
+	Examples: []FuncExample{
+		{Selector: `toFloat("1.5")`, Output: "1.5"},
+	},
+})
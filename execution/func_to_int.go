@@ -47,4 +47,14 @@ var FuncToInt = NewFunc(
 		}
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a value to an int. Floats are truncated.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "int",
+	Examples: []FuncExample{
+		{Selector: `toInt("42")`, Output: "42"},
+		{Selector: "toInt(1.9)", Output: "1"},
+	},
+})
@@ -28,4 +28,13 @@ var FuncToLower = NewFunc(
 		return model.NewStringValue(strings.ToLower(input)), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a string to lower case.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `toLower("HeLLo")`, Output: `"hello"`},
+	},
+})
@@ -11,35 +11,51 @@ import (
 var FuncToString = NewFunc(
 	"toString",
 	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
-		switch args[0].Type() {
-		case model.TypeString:
-			stringValue, err := args[0].StringValue()
-			if err != nil {
-				return nil, err
-			}
-			model.NewStringValue(stringValue)
+		if args[0].IsString() {
 			return args[0], nil
-		case model.TypeInt:
-			i, err := args[0].IntValue()
-			if err != nil {
-				return nil, err
-			}
-			return model.NewStringValue(fmt.Sprintf("%d", i)), nil
-		case model.TypeFloat:
-			i, err := args[0].FloatValue()
-			if err != nil {
-				return nil, err
-			}
-			return model.NewStringValue(fmt.Sprintf("%g", i)), nil
-		case model.TypeBool:
-			i, err := args[0].BoolValue()
-			if err != nil {
-				return nil, err
-			}
-			return model.NewStringValue(fmt.Sprintf("%v", i)), nil
-		default:
-			return nil, fmt.Errorf("cannot convert %s to string", args[0].Type())
 		}
+		s, err := valueToString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return model.NewStringValue(s), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a scalar value to a string.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: "toString(42)", Output: `"42"`},
+	},
+})
+
+// valueToString converts a scalar value to a string.
+func valueToString(v *model.Value) (string, error) {
+	switch v.Type() {
+	case model.TypeString:
+		return v.StringValue()
+	case model.TypeInt:
+		i, err := v.IntValue()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d", i), nil
+	case model.TypeFloat:
+		i, err := v.FloatValue()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%g", i), nil
+	case model.TypeBool:
+		i, err := v.BoolValue()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v", i), nil
+	default:
+		return "", fmt.Errorf("cannot convert %s to string", v.Type())
+	}
+}
@@ -28,4 +28,13 @@ var FuncToUpper = NewFunc(
 		return model.NewStringValue(strings.ToUpper(input)), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Converts a string to upper case.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `toUpper("hello")`, Output: `"HELLO"`},
+	},
+})
@@ -28,7 +28,16 @@ var FuncTrim = NewFunc(
 		return model.NewStringValue(strings.TrimSpace(input)), nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Removes leading and trailing whitespace from a string.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `trim("  hi  ")`, Output: `"hi"`},
+	},
+})
 
 // FuncTrimPrefix is a function that trims a prefix from a string.
 var FuncTrimPrefix = NewFunc(
@@ -61,7 +70,17 @@ var FuncTrimPrefix = NewFunc(
 		return model.NewStringValue(strings.TrimPrefix(input, prefix)), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Removes a prefix from a string, if present.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "prefix", Type: "string"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `trimPrefix("v1.2", "v")`, Output: `"1.2"`},
+	},
+})
 
 // FuncTrimSuffix is a function that trims a suffix from a string.
 var FuncTrimSuffix = NewFunc(
@@ -94,4 +113,14 @@ var FuncTrimSuffix = NewFunc(
 		return model.NewStringValue(strings.TrimSuffix(input, suffix)), nil
 	},
 	ValidateArgsMinMax(1, 2),
-)
+).WithDoc(FuncDoc{
+	Description: "Removes a suffix from a string, if present.",
+	Params: []FuncParam{
+		{Name: "value", Type: "string", Optional: true},
+		{Name: "suffix", Type: "string"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: `trimSuffix("app.yaml", ".yaml")`, Output: `"app"`},
+	},
+})
@@ -12,4 +12,13 @@ var FuncTypeOf = NewFunc(
 		return model.NewStringValue(args[0].Type().String()), nil
 	},
 	ValidateArgsExactly(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the type of a value, e.g. string, int, float, bool, map, array or null.",
+	Params: []FuncParam{
+		{Name: "value", Type: "any"},
+	},
+	Returns: "string",
+	Examples: []FuncExample{
+		{Selector: "typeOf([1])", Output: `"array"`},
+	},
+})
@@ -0,0 +1,271 @@
+package execution
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// ErrArgumentType is returned when a typed function is given an argument that cannot be converted to its Go type.
+type ErrArgumentType struct {
+	// Index is the 1-based position of the argument.
+	Index int
+	// Path is the location of the mismatched value within the argument, e.g. [2] or .name.
+	// It is empty when the argument itself has the wrong type.
+	Path     string
+	Expected string
+	Actual   model.Type
+}
+
+// Error returns the error message.
+func (e ErrArgumentType) Error() string {
+	return fmt.Sprintf("expected argument %d%s to be %s, got %s", e.Index, e.Path, e.Expected, e.Actual)
+}
+
+// NewTypedFunc1 creates a new Func that accepts exactly one argument.
+// The argument is converted to A and the result of fn is converted back to a value.
+//
+// Supported types are string, bool, int, uint and float types, *model.Value, any,
+// pointers, slices and string keyed maps of supported types, and structs whose exported fields are supported types.
+// Arguments are converted using model.Value.Decode, so floats accept int values, pointers accept null,
+// struct fields are matched by their dasel, json or yaml tags and any is converted using model.Value.GoValue.
+// Structs are returned as maps of their exported fields, as with model.NewValue.
+// The parameters and return type of the Func are documented from the Go types.
+// NewTypedFunc1 panics if A or R is not supported.
+func NewTypedFunc1[A, R any](name string, fn func(ctx context.Context, a A) (R, error)) *Func {
+	types := mustTypedArgs(name, reflect.TypeFor[A](), reflect.TypeFor[R]())
+	return newTypedFunc(name, types, func(ctx context.Context, args []reflect.Value) (R, error) {
+		return fn(ctx, typedArg[A](args[0]))
+	})
+}
+
+// NewTypedFunc2 creates a new Func that accepts exactly two arguments.
+// The arguments are converted to A and B and the result of fn is converted back to a value.
+// See NewTypedFunc1 for the supported types.
+func NewTypedFunc2[A, B, R any](name string, fn func(ctx context.Context, a A, b B) (R, error)) *Func {
+	types := mustTypedArgs(name, reflect.TypeFor[A](), reflect.TypeFor[B](), reflect.TypeFor[R]())
+	return newTypedFunc(name, types, func(ctx context.Context, args []reflect.Value) (R, error) {
+		return fn(ctx, typedArg[A](args[0]), typedArg[B](args[1]))
+	})
+}
+
+// mustTypedArgs panics if any of the types cannot be converted to and from values.
+// It returns the argument types, which are all but the last.
+func mustTypedArgs(name string, types ...reflect.Type) []reflect.Type {
+	for _, t := range types {
+		if !isTypedSupported(t) {
+			panic(fmt.Sprintf("typed func %q: unsupported type %s", name, t))
+		}
+	}
+	return types[:len(types)-1]
+}
+
+// typedArg returns the converted argument as T.
+// A nil interface gives the zero value, since it cannot be asserted.
+func typedArg[T any](v reflect.Value) T {
+	res, _ := v.Interface().(T)
+	return res
+}
+
+func newTypedFunc[R any](name string, types []reflect.Type, call func(ctx context.Context, args []reflect.Value) (R, error)) *Func {
+	params := make([]FuncParam, len(types))
+	for i, t := range types {
+		params[i] = FuncParam{Name: "arg" + strconv.Itoa(i+1), Type: typedName(t)}
+	}
+
+	return NewFunc(
+		name,
+		func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+			converted := make([]reflect.Value, len(types))
+			for i, t := range types {
+				v, err := fromValue(args[i], t)
+				if err != nil {
+					var decodeErr model.DecodeError
+					if errors.As(err, &decodeErr) && decodeErr.Err == nil {
+						return nil, ErrArgumentType{
+							Index:    i + 1,
+							Path:     argumentPath(decodeErr.Path),
+							Expected: typedName(decodeErr.Type),
+							Actual:   decodeErr.Actual,
+						}
+					}
+					return nil, fmt.Errorf("error reading argument %d: %w", i+1, err)
+				}
+				converted[i] = v
+			}
+
+			res, err := call(ctx, converted)
+			if err != nil {
+				return nil, err
+			}
+			return toValue(reflect.ValueOf(&res).Elem())
+		},
+		ValidateArgsExactly(len(types)),
+	).WithDoc(FuncDoc{
+		Params:  params,
+		Returns: typedName(reflect.TypeFor[R]()),
+	})
+}
+
+// argumentPath returns the path given by model.DecodeError as it is written after an argument number,
+// e.g. users[1].age becomes .users[1].age.
+func argumentPath(path string) string {
+	if path == "" || strings.HasPrefix(path, "[") {
+		return path
+	}
+	return "." + path
+}
+
+var (
+	valueType           = reflect.TypeFor[*model.Value]()
+	anyType             = reflect.TypeFor[any]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func isTypedSupported(t reflect.Type) bool {
+	return typedSupported(t, map[reflect.Type]bool{})
+}
+
+// typedSupported reports whether t is supported. seen holds the struct types already being checked,
+// so that recursive types terminate.
+func typedSupported(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == valueType || t == anyType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer, reflect.Slice:
+		return typedSupported(t.Elem(), seen)
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && typedSupported(t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] || isTextType(t) {
+			return true
+		}
+		seen[t] = true
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && !typedSupported(f.Type, seen) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isTextType reports whether t is converted to and from a string, such as time.Time.
+func isTextType(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType) && t.Implements(textMarshalerType)
+}
+
+// typedName returns the name used for the type in documentation and errors.
+func typedName(t reflect.Type) string {
+	if t == valueType || t == anyType {
+		return "any"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return model.TypeString.String()
+	case reflect.Bool:
+		return model.TypeBool.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return model.TypeInt.String()
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Pointer:
+		return typedName(t.Elem())
+	case reflect.Slice:
+		return model.TypeSlice.String()
+	case reflect.Map:
+		return model.TypeMap.String()
+	case reflect.Struct:
+		if isTextType(t) {
+			return model.TypeString.String()
+		}
+		return model.TypeMap.String()
+	}
+	return t.String()
+}
+
+// fromValue converts v to the Go type t using model.Value.Decode.
+func fromValue(v *model.Value, t reflect.Type) (reflect.Value, error) {
+	res := reflect.New(t)
+	if err := v.Decode(res.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return res.Elem(), nil
+}
+
+// toValue converts the Go value v to a value.
+// Map keys are sorted so that the result is stable.
+func toValue(v reflect.Value) (*model.Value, error) {
+	if v.Type() == valueType {
+		if v.IsNil() {
+			return model.NewNullValue(), nil
+		}
+		return v.Interface().(*model.Value), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return model.NewStringValue(v.String()), nil
+	case reflect.Bool:
+		return model.NewBoolValue(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return model.NewIntValue(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return model.NewIntValue(int64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return model.NewFloatValue(v.Float()), nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return model.NewNullValue(), nil
+		}
+		return toValue(v.Elem())
+	case reflect.Slice:
+		res := model.NewSliceValue()
+		for i := range v.Len() {
+			item, err := toValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if err := res.Append(item); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return model.NewValue(v.Interface()), nil
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		res := model.NewMapValue()
+		for _, key := range keys {
+			item, err := toValue(v.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			if err := res.SetMapKey(key.String(), item); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	return model.NewValue(v.Interface()), nil
+}
@@ -0,0 +1,240 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+type typedUser struct {
+	Name    string      `json:"name"`
+	Age     int         `json:"age"`
+	Friends []typedUser `json:"friends,omitempty"`
+}
+
+func TestTypedFunc(t *testing.T) {
+	funcs := execution.DefaultFuncCollection.Copy().Register(
+		execution.NewTypedFunc1("birthday", func(ctx context.Context, u typedUser) (typedUser, error) {
+			u.Age++
+			return u, nil
+		}),
+		execution.NewTypedFunc1("double", func(ctx context.Context, x int) (int, error) {
+			return x * 2, nil
+		}),
+		execution.NewTypedFunc1("half", func(ctx context.Context, x float64) (float64, error) {
+			return x / 2, nil
+		}),
+		execution.NewTypedFunc2("repeat", func(ctx context.Context, s string, n int) (string, error) {
+			if n < 0 {
+				return "", fmt.Errorf("count must not be negative")
+			}
+			return strings.Repeat(s, n), nil
+		}),
+		execution.NewTypedFunc1("upperAll", func(ctx context.Context, s []string) ([]string, error) {
+			res := make([]string, len(s))
+			for i, v := range s {
+				res[i] = strings.ToUpper(v)
+			}
+			return res, nil
+		}),
+		execution.NewTypedFunc1("total", func(ctx context.Context, m map[string]int) (map[string]int, error) {
+			sum := 0
+			for _, v := range m {
+				sum += v
+			}
+			return map[string]int{"total": sum, "count": len(m)}, nil
+		}),
+		execution.NewTypedFunc1("orDefault", func(ctx context.Context, s *string) (string, error) {
+			if s == nil {
+				return "default", nil
+			}
+			return *s, nil
+		}),
+		execution.NewTypedFunc1("small", func(ctx context.Context, x int8) (int8, error) {
+			return x, nil
+		}),
+		execution.NewTypedFunc2("pair", func(ctx context.Context, a any, b *model.Value) ([]any, error) {
+			return []any{a, b}, nil
+		}),
+	)
+	opts := []execution.ExecuteOptionFn{execution.WithFuncs(funcs)}
+
+	t.Run("int", testCase{
+		s:    `double(21)`,
+		out:  model.NewIntValue(42),
+		opts: opts,
+	}.run)
+	t.Run("float accepts int", testCase{
+		s:    `half(3)`,
+		out:  model.NewFloatValue(1.5),
+		opts: opts,
+	}.run)
+	t.Run("two arguments", testCase{
+		s:    `repeat("ab", 3)`,
+		out:  model.NewStringValue("ababab"),
+		opts: opts,
+	}.run)
+	t.Run("slice", testCase{
+		s: `upperAll(["a", "b"])`,
+		outFn: func() *model.Value {
+			res := model.NewSliceValue()
+			_ = res.Append(model.NewStringValue("A"))
+			_ = res.Append(model.NewStringValue("B"))
+			return res
+		},
+		opts: opts,
+	}.run)
+	t.Run("map keys are sorted", testCase{
+		s: `total({"a": 1, "b": 2})`,
+		out: model.NewValue(orderedmap.NewMap().
+			Set("count", int64(2)).
+			Set("total", int64(3))),
+		opts: opts,
+	}.run)
+	t.Run("pointer accepts null", testCase{
+		s:    `orDefault(null)`,
+		out:  model.NewStringValue("default"),
+		opts: opts,
+	}.run)
+	t.Run("pointer", testCase{
+		s:    `orDefault("x")`,
+		out:  model.NewStringValue("x"),
+		opts: opts,
+	}.run)
+	t.Run("any and value", testCase{
+		s: `pair(null, 1)`,
+		outFn: func() *model.Value {
+			res := model.NewSliceValue()
+			_ = res.Append(model.NewNullValue())
+			_ = res.Append(model.NewIntValue(1))
+			return res
+		},
+		opts: opts,
+	}.run)
+
+	t.Run("struct", testCase{
+		s:    `birthday({"name": "Tom", "age": 31})`,
+		out:  model.NewValue(orderedmap.NewMap().Set("name", "Tom").Set("age", int64(32))),
+		opts: opts,
+	}.run)
+
+	t.Run("errors", func(t *testing.T) {
+		run := func(selector string) error {
+			_, err := execution.ExecuteSelector(context.Background(), selector, model.NewNullValue(), execution.NewOptions(opts...))
+			return err
+		}
+
+		testCases := []struct {
+			name     string
+			selector string
+			exp      execution.ErrArgumentType
+		}{
+			{
+				name:     "argument",
+				selector: `repeat("a", "b")`,
+				exp:      execution.ErrArgumentType{Index: 2, Expected: "int", Actual: model.TypeString},
+			},
+			{
+				name:     "slice element",
+				selector: `upperAll(["a", 1])`,
+				exp:      execution.ErrArgumentType{Index: 1, Path: "[1]", Expected: "string", Actual: model.TypeInt},
+			},
+			{
+				name:     "struct field",
+				selector: `birthday({"name": "Tom", "age": "x"})`,
+				exp:      execution.ErrArgumentType{Index: 1, Path: ".age", Expected: "int", Actual: model.TypeString},
+			},
+			{
+				name:     "map value",
+				selector: `total({"a": 1, "b": "x"})`,
+				exp:      execution.ErrArgumentType{Index: 1, Path: ".b", Expected: "int", Actual: model.TypeString},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := run(tc.selector)
+				var got execution.ErrArgumentType
+				if !errors.As(err, &got) {
+					t.Fatalf("expected ErrArgumentType, got %v", err)
+				}
+				if got != tc.exp {
+					t.Errorf("expected %+v, got %+v", tc.exp, got)
+				}
+			})
+		}
+
+		t.Run("message", func(t *testing.T) {
+			err := run(`upperAll(["a", 1])`)
+			if exp := `expected argument 1[1] to be string, got int`; err == nil || !strings.Contains(err.Error(), exp) {
+				t.Errorf("expected error containing %q, got %v", exp, err)
+			}
+		})
+
+		t.Run("overflow", func(t *testing.T) {
+			err := run(`small(300)`)
+			if exp := `error reading argument 1: cannot decode int into int8: 300 overflows int8`; err == nil || !strings.Contains(err.Error(), exp) {
+				t.Errorf("expected error containing %q, got %v", exp, err)
+			}
+		})
+
+		t.Run("argument count", func(t *testing.T) {
+			err := run(`double(1, 2)`)
+			if exp := `func "double" expects exactly 1 arguments, got 2`; err == nil || !strings.Contains(err.Error(), exp) {
+				t.Errorf("expected error containing %q, got %v", exp, err)
+			}
+		})
+
+		t.Run("function error", func(t *testing.T) {
+			err := run(`repeat("a", -1)`)
+			if exp := `count must not be negative`; err == nil || !strings.Contains(err.Error(), exp) {
+				t.Errorf("expected error containing %q, got %v", exp, err)
+			}
+		})
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		f := execution.NewTypedFunc2("repeat", func(ctx context.Context, s string, n int) ([]string, error) {
+			return nil, nil
+		})
+		if exp, got := "repeat(arg1 string, arg2 int) array", f.Signature(); got != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+	})
+
+	t.Run("struct signature", func(t *testing.T) {
+		f := execution.NewTypedFunc1("birthday", func(ctx context.Context, u typedUser) (typedUser, error) {
+			return u, nil
+		})
+		if exp, got := "birthday(arg1 map) map", f.Signature(); got != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+	})
+
+	t.Run("unsupported type panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+		execution.NewTypedFunc1("f", func(ctx context.Context, c chan int) (int, error) {
+			return 0, nil
+		})
+	})
+
+	t.Run("struct with unsupported field panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+		execution.NewTypedFunc1("f", func(ctx context.Context, s struct{ C chan int }) (int, error) {
+			return 0, nil
+		})
+	})
+}
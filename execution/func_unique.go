@@ -46,4 +46,13 @@ var FuncUnique = NewFunc(
 		return res, nil
 	},
 	ValidateArgsMax(1),
-)
+).WithDoc(FuncDoc{
+	Description: "Removes duplicate values from an array, keeping the first occurrence.",
+	Params: []FuncParam{
+		{Name: "array", Type: "array", Optional: true},
+	},
+	Returns: "array",
+	Examples: []FuncExample{
+		{Selector: "unique([1, 2, 1])", Output: "[1, 2]"},
+	},
+})
@@ -0,0 +1,29 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// FuncValidate is a function that validates the input against a JSON Schema.
+// The input is returned unchanged if it is valid, otherwise an error describing each failure is returned.
+var FuncValidate = NewFunc(
+	"validate",
+	func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+		if err := ValidateSchema(data, args[0]); err != nil {
+			return nil, err
+		}
+		return data, nil
+	},
+	ValidateArgsExactly(1),
+).WithDoc(FuncDoc{
+	Description: "Validates the current value against a JSON Schema (draft 2020-12). The value is returned unchanged if it is valid, otherwise an error describing each failure is returned.",
+	Params: []FuncParam{
+		{Name: "schema", Type: "map"},
+	},
+	Returns: "any",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1}.validate({"type": "object"})`, Output: `{"a": 1}`},
+	},
+})
@@ -0,0 +1,164 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+	_ "github.com/tomwright/dasel/v3/parsing/json"
+)
+
+func TestFuncValidate(t *testing.T) {
+	t.Run("valid returns input", testCase{
+		s: `{"name":"app","replicas":2}.validate({"type":"object","required":["name"],"properties":{"replicas":{"type":"integer","minimum":1}}})`,
+		outFn: func() *model.Value {
+			return model.NewValue(orderedmap.NewMap().Set("name", "app").Set("replicas", int64(2)))
+		},
+	}.run)
+
+	schema := model.NewValue(orderedmap.NewMap().
+		Set("$defs", orderedmap.NewMap().
+			Set("port", orderedmap.NewMap().
+				Set("type", "integer").
+				Set("minimum", int64(1)).
+				Set("maximum", int64(65535)))).
+		Set("type", "object").
+		Set("required", []any{"name", "env"}).
+		Set("additionalProperties", false).
+		Set("properties", orderedmap.NewMap().
+			Set("name", orderedmap.NewMap().Set("type", "string").Set("pattern", "^[a-z]+$")).
+			Set("env", orderedmap.NewMap().Set("enum", []any{"dev", "prod"})).
+			Set("ports", orderedmap.NewMap().
+				Set("type", "array").
+				Set("uniqueItems", true).
+				Set("items", orderedmap.NewMap().Set("$ref", "#/$defs/port"))).
+			Set("labels", orderedmap.NewMap().
+				Set("type", "object").
+				Set("additionalProperties", orderedmap.NewMap().Set("type", "string"))).
+			Set("replicas", orderedmap.NewMap().
+				Set("oneOf", []any{
+					orderedmap.NewMap().Set("type", "integer"),
+					orderedmap.NewMap().Set("const", "auto"),
+				})).
+			Set("timeout", orderedmap.NewMap().
+				Set("anyOf", []any{
+					orderedmap.NewMap().Set("type", "integer"),
+					orderedmap.NewMap().Set("type", "null"),
+				})).
+			Set("mode", orderedmap.NewMap().
+				Set("allOf", []any{
+					orderedmap.NewMap().Set("type", "string"),
+					orderedmap.NewMap().Set("minLength", int64(3)),
+				}).
+				Set("not", orderedmap.NewMap().Set("const", "off")))))
+
+	validate := func(t *testing.T, doc *model.Value) []execution.SchemaError {
+		t.Helper()
+		err := execution.ValidateSchema(doc, schema)
+		if err == nil {
+			return nil
+		}
+		var validationErr execution.ErrSchemaValidation
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected ErrSchemaValidation, got %v", err)
+		}
+		return validationErr.Errors
+	}
+
+	t.Run("valid document", func(t *testing.T) {
+		doc := model.NewValue(orderedmap.NewMap().
+			Set("name", "app").
+			Set("env", "dev").
+			Set("ports", []any{int64(80), int64(443)}).
+			Set("labels", orderedmap.NewMap().Set("team", "core")).
+			Set("replicas", "auto").
+			Set("timeout", nil).
+			Set("mode", "fast"))
+		if errs := validate(t, doc); len(errs) > 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+	})
+
+	t.Run("errors include paths", func(t *testing.T) {
+		doc := model.NewValue(orderedmap.NewMap().
+			Set("name", "App").
+			Set("ports", []any{int64(80), int64(70000), int64(80)}).
+			Set("labels", orderedmap.NewMap().Set("team name", int64(1))).
+			Set("replicas", 1.5).
+			Set("timeout", "soon").
+			Set("mode", "off").
+			Set("extra", true))
+		got := validate(t, doc)
+		exp := []execution.SchemaError{
+			{Path: "$root", Keyword: "required", Message: `missing required property "env"`},
+			{Path: "name", Keyword: "pattern", Message: `"App" does not match pattern "^[a-z]+$"`},
+			{Path: "ports[1]", Keyword: "maximum", Message: "value 70000 must be <= 65535"},
+			{Path: "ports[2]", Keyword: "uniqueItems", Message: "duplicate item"},
+			{Path: `labels["team name"]`, Keyword: "type", Message: "expected string, got integer"},
+			{Path: "replicas", Keyword: "oneOf", Message: "value must match exactly one schema, matched 0"},
+			{Path: "timeout", Keyword: "anyOf", Message: "value does not match any of the schemas"},
+			{Path: "timeout", Keyword: "type", Message: "expected integer, got string"},
+			{Path: "timeout", Keyword: "type", Message: "expected null, got string"},
+			{Path: "mode", Keyword: "not", Message: "value must not match the schema"},
+			{Path: "$root", Keyword: "additionalProperties", Message: `additional property "extra" is not allowed`},
+		}
+		if !cmp.Equal(exp, got) {
+			t.Errorf("unexpected errors: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("enum error", func(t *testing.T) {
+		doc := model.NewValue(orderedmap.NewMap().Set("name", "app").Set("env", "staging"))
+		got := validate(t, doc)
+		exp := []execution.SchemaError{
+			{Path: "env", Keyword: "enum", Message: `value "staging" is not one of ["dev","prod"]`},
+		}
+		if !cmp.Equal(exp, got) {
+			t.Errorf("unexpected errors: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("invalid from selector", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `{"a":"x"}.validate({"properties":{"a":{"type":"number"}}})`, model.NewNullValue(), execution.NewOptions())
+		var validationErr execution.ErrSchemaValidation
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected ErrSchemaValidation, got %v", err)
+		}
+	})
+
+	t.Run("unsupported ref", func(t *testing.T) {
+		err := execution.ValidateSchema(model.NewNullValue(), model.NewValue(orderedmap.NewMap().Set("$ref", "other.json")))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("recursive ref", func(t *testing.T) {
+		tree := model.NewValue(orderedmap.NewMap().
+			Set("$defs", orderedmap.NewMap().
+				Set("node", orderedmap.NewMap().
+					Set("$anchor", "node").
+					Set("type", "object").
+					Set("properties", orderedmap.NewMap().
+						Set("value", orderedmap.NewMap().Set("type", "integer")).
+						Set("children", orderedmap.NewMap().
+							Set("type", "array").
+							Set("items", orderedmap.NewMap().Set("$ref", "#node")))))).
+			Set("$ref", "#/$defs/node"))
+		doc := model.NewValue(orderedmap.NewMap().
+			Set("value", int64(1)).
+			Set("children", []any{orderedmap.NewMap().Set("value", "x")}))
+		err := execution.ValidateSchema(doc, tree)
+		var validationErr execution.ErrSchemaValidation
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected ErrSchemaValidation, got %v", err)
+		}
+		if got := validationErr.Errors[0].Path; got != "children[0].value" {
+			t.Errorf("unexpected path: %s", got)
+		}
+	})
+}
@@ -25,4 +25,10 @@ var FuncValues = NewFunc(
 		return res, nil
 	},
 	ValidateArgsExactly(0),
-)
+).WithDoc(FuncDoc{
+	Description: "Returns the values of the current map as an array.",
+	Returns:     "array",
+	Examples: []FuncExample{
+		{Selector: `{"a": 1, "b": 2}.values()`, Output: "[1, 2]"},
+	},
+})
@@ -0,0 +1,119 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// DefaultMaxDepth is the maximum depth that expressions may be nested when Options.MaxDepth is zero.
+// It applies to every execution and protects against unbounded recursion in user defined functions.
+// Each call to a user defined function nests a few expressions, so recursion is limited to a few hundred calls.
+const DefaultMaxDepth = 1000
+
+// ErrMaxStepsExceeded is returned when an execution evaluates more expressions than allowed by Options.MaxSteps.
+type ErrMaxStepsExceeded struct {
+	Max int
+}
+
+// Error returns the error message.
+func (e ErrMaxStepsExceeded) Error() string {
+	return fmt.Sprintf("max steps of %d exceeded", e.Max)
+}
+
+// ErrMaxDepthExceeded is returned when expressions are nested or recurse deeper than allowed by Options.MaxDepth.
+type ErrMaxDepthExceeded struct {
+	Max int
+}
+
+// Error returns the error message.
+func (e ErrMaxDepthExceeded) Error() string {
+	return fmt.Sprintf("max depth of %d exceeded", e.Max)
+}
+
+// maxDepthError returns the ErrMaxDepthExceeded within err, if there is one.
+// It is returned in place of err so that the error isn't wrapped again at each level of a deep recursion.
+func maxDepthError(err error) (ErrMaxDepthExceeded, bool) {
+	var depthErr ErrMaxDepthExceeded
+	ok := errors.As(err, &depthErr)
+	return depthErr, ok
+}
+
+// ErrMaxOutputSizeExceeded is returned when the result of an execution contains more values than allowed by Options.MaxOutputSize.
+type ErrMaxOutputSizeExceeded struct {
+	Max int
+}
+
+// Error returns the error message.
+func (e ErrMaxOutputSizeExceeded) Error() string {
+	return fmt.Sprintf("max output size of %d values exceeded", e.Max)
+}
+
+// usage tracks the resources used by a single execution.
+type usage struct {
+	steps int
+	depth int
+}
+
+// enter records the evaluation of an expression.
+// It returns an error if the context is done or a limit has been exceeded.
+// If no error is returned, leave must be called once the expression has been evaluated.
+func (o *Options) enter(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if o.usage == nil {
+		return nil
+	}
+	o.usage.steps++
+	if o.MaxSteps > 0 && o.usage.steps > o.MaxSteps {
+		return ErrMaxStepsExceeded{Max: o.MaxSteps}
+	}
+	maxDepth := o.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	if o.usage.depth >= maxDepth {
+		return ErrMaxDepthExceeded{Max: maxDepth}
+	}
+	o.usage.depth++
+	return nil
+}
+
+// leave records that the evaluation of an expression has finished.
+func (o *Options) leave() {
+	if o.usage != nil {
+		o.usage.depth--
+	}
+}
+
+// checkOutputSize returns an ErrMaxOutputSizeExceeded if the value contains more values than allowed by
+// Options.MaxOutputSize. Nested map and slice values are included in the count.
+func checkOutputSize(options *Options, value *model.Value) error {
+	if options.MaxOutputSize <= 0 {
+		return nil
+	}
+	count := 0
+	var visit func(v *model.Value) error
+	visit = func(v *model.Value) error {
+		count++
+		if count > options.MaxOutputSize {
+			return ErrMaxOutputSizeExceeded{Max: options.MaxOutputSize}
+		}
+		switch v.Type() {
+		case model.TypeMap:
+			return v.RangeMap(func(_ string, v *model.Value) error {
+				return visit(v)
+			})
+		case model.TypeSlice:
+			return v.RangeSlice(func(_ int, v *model.Value) error {
+				return visit(v)
+			})
+		default:
+			return nil
+		}
+	}
+	return visit(value)
+}
@@ -0,0 +1,113 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestLimits(t *testing.T) {
+	t.Run("max steps exceeded", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithMaxSteps(5))
+		_, err := execution.ExecuteSelector(context.Background(), `[1, 2, 3, 4, 5].map($this * 2)`, model.NewNullValue(), opts)
+		var target execution.ErrMaxStepsExceeded
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrMaxStepsExceeded, got %v", err)
+		}
+		if target.Max != 5 {
+			t.Errorf("expected max 5, got %d", target.Max)
+		}
+	})
+
+	t.Run("within max steps", testCase{
+		s:    `1 + 2`,
+		out:  model.NewIntValue(3),
+		opts: []execution.ExecuteOptionFn{execution.WithMaxSteps(3)},
+	}.run)
+
+	t.Run("steps are counted per execution", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithMaxSteps(3))
+		for i := 0; i < 3; i++ {
+			if _, err := execution.ExecuteSelector(context.Background(), `1 + 2`, model.NewNullValue(), opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("max depth exceeded by recursion", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithMaxDepth(50))
+		_, err := execution.ExecuteSelector(context.Background(), `def f(n) = f(($n + 1)); f(0)`, model.NewNullValue(), opts)
+		var target execution.ErrMaxDepthExceeded
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrMaxDepthExceeded, got %v", err)
+		}
+		if exp := "error executing selector: max depth of 50 exceeded"; err.Error() != exp {
+			t.Errorf("expected %q, got %q", exp, err.Error())
+		}
+	})
+
+	t.Run("default max depth", func(t *testing.T) {
+		_, err := execution.ExecuteSelector(context.Background(), `def f(n) = f(($n + 1)); f(0)`, model.NewNullValue(), execution.NewOptions())
+		var target execution.ErrMaxDepthExceeded
+		if !errors.As(err, &target) || target.Max != execution.DefaultMaxDepth {
+			t.Fatalf("expected ErrMaxDepthExceeded with the default max, got %v", err)
+		}
+	})
+
+	t.Run("within max depth", testCase{
+		s:    `[1, 2, 3].map($this * 2).sum($this...)`,
+		out:  model.NewIntValue(12),
+		opts: []execution.ExecuteOptionFn{execution.WithMaxDepth(10)},
+	}.run)
+
+	t.Run("max output size exceeded", func(t *testing.T) {
+		opts := execution.NewOptions(execution.WithMaxOutputSize(3))
+		_, err := execution.ExecuteSelector(context.Background(), `[1, 2, 3]`, model.NewNullValue(), opts)
+		var target execution.ErrMaxOutputSizeExceeded
+		if !errors.As(err, &target) {
+			t.Fatalf("expected ErrMaxOutputSizeExceeded, got %v", err)
+		}
+	})
+
+	t.Run("within max output size", testCase{
+		s: `{"a": [1]}`,
+		outFn: func() *model.Value {
+			s := model.NewSliceValue()
+			_ = s.Append(model.NewIntValue(1))
+			m := model.NewMapValue()
+			_ = m.SetMapKey("a", s)
+			return m
+		},
+		opts: []execution.ExecuteOptionFn{execution.WithMaxOutputSize(3)},
+	}.run)
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := execution.ExecuteSelector(ctx, `[1, 2, 3].map($this * 2)`, model.NewNullValue(), execution.NewOptions())
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("cancelled during execution", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := model.NewSliceValue()
+		for i := 0; i < 3; i++ {
+			_ = in.Append(model.NewIntValue(int64(i)))
+		}
+		opts := execution.NewOptions(execution.WithFuncs(execution.DefaultFuncCollection.Copy().Register(
+			execution.NewFunc("cancel", func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+				cancel()
+				return data, nil
+			}, execution.ValidateArgsExactly(0)),
+		)))
+		_, err := execution.ExecuteSelector(ctx, `cancel()..*`, in, opts)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package execution
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/tomwright/dasel/v3/selector"
+)
+
+// module is a selector file that has been read, parsed and compiled for import.
+type module struct {
+	executor expressionExecutor
+	// unstable is true if the module uses unstable features.
+	unstable bool
+}
+
+// moduleCache holds the imports of a program, so that each file is resolved, read and compiled
+// once rather than on every execution. It is safe for concurrent use.
+// A nil cache resolves and compiles every import as it is used.
+type moduleCache struct {
+	mu sync.Mutex
+	// paths maps an import, as seen from the importing directory with the given lib paths, to its absolute path.
+	paths map[string]string
+	// modules maps the absolute path of a file to its compiled module.
+	modules map[string]*module
+}
+
+func newModuleCache() *moduleCache {
+	return &moduleCache{
+		paths:   map[string]string{},
+		modules: map[string]*module{},
+	}
+}
+
+// resolve returns the absolute path of the imported file, using resolveFn if it hasn't been resolved before.
+func (c *moduleCache) resolve(dir string, path string, libPaths []string, resolveFn func() (string, error)) (string, error) {
+	if c == nil {
+		return resolveFn()
+	}
+	key := strings.Join(append([]string{dir, path}, libPaths...), "\x00")
+
+	c.mu.Lock()
+	resolved, ok := c.paths[key]
+	c.mu.Unlock()
+	if ok {
+		return resolved, nil
+	}
+
+	resolved, err := resolveFn()
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.paths[key] = resolved
+	c.mu.Unlock()
+	return resolved, nil
+}
+
+// load returns the compiled module for the file at the given absolute path.
+// importPath is the path as written in the import, and is used in errors.
+// Errors are not cached, so a failed import is retried by the next execution.
+func (c *moduleCache) load(path string, importPath string) (*module, error) {
+	if c != nil {
+		c.mu.Lock()
+		m, ok := c.modules[path]
+		c.mu.Unlock()
+		if ok {
+			return m, nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading import %q: %w", importPath, err)
+	}
+	expr, err := selector.Parse(string(content))
+	if err != nil {
+		return nil, ModuleError{Path: path, Err: fmt.Errorf("error parsing import %q: %w", importPath, err)}
+	}
+	executor, err := compileAST(expr)
+	if err != nil {
+		return nil, ModuleError{Path: path, Err: fmt.Errorf("error compiling import %q: %w", importPath, err)}
+	}
+	m := &module{executor: executor, unstable: usesUnstable(expr)}
+
+	if c != nil {
+		c.mu.Lock()
+		c.modules[path] = m
+		c.mu.Unlock()
+	}
+	return m, nil
+}
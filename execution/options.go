@@ -1,15 +1,49 @@
 package execution
 
-import "github.com/tomwright/dasel/v3/model"
+import (
+	"maps"
+	"slices"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
 
 // ExecuteOptionFn is a function that can be used to set options on the execution of the selector.
 type ExecuteOptionFn func(*Options)
 
 // Options contains the options for the execution of the selector.
 type Options struct {
-	Funcs    FuncCollection
+	Funcs FuncCollection
+	// Vars are the variables available to the selector.
+	// They are copied into the scope of each execution and are never modified by it.
 	Vars     map[string]*model.Value
 	Unstable bool
+	// LibPaths are the directories searched for imported selector files
+	// that cannot be found relative to the importing file.
+	LibPaths []string
+	// MaxSteps is the maximum number of expressions that may be evaluated by an execution.
+	// Zero means no limit.
+	MaxSteps int
+	// MaxDepth is the maximum depth that expressions may be nested, including through recursion.
+	// Zero means DefaultMaxDepth, so a depth limit always applies.
+	MaxDepth int
+	// MaxOutputSize is the maximum number of values, including nested values, in the result of an execution.
+	// Zero means no limit.
+	MaxOutputSize int
+	// Tracer is called before and after each expression is evaluated.
+	Tracer Tracer
+
+	// imports contains the imports that are loaded before the selector is executed.
+	imports []ast.ImportExpr
+	// modules caches the files imported by the program being executed.
+	modules *moduleCache
+	// scope contains the variables and functions visible to the current expression.
+	scope *scope
+	// paths contains the location within the input of each selected value.
+	// It is nil unless the program uses $path.
+	paths map[*model.Value][]any
+	// usage tracks the resources used by the current execution.
+	usage *usage
 }
 
 // NewOptions creates a new Options struct with the given options.
@@ -41,6 +75,50 @@ func WithVariable(key string, val *model.Value) ExecuteOptionFn {
 	}
 }
 
+// WithLibPaths adds directories that are searched for imported selector files.
+func WithLibPaths(dirs ...string) ExecuteOptionFn {
+	return func(o *Options) {
+		o.LibPaths = append(o.LibPaths, dirs...)
+	}
+}
+
+// WithImport imports the selector file at path before the selector is executed,
+// exposing its definitions and variables under the given alias.
+// The file is executed in its own scope, so it cannot see the variables given by WithVariable.
+func WithImport(path string, alias string) ExecuteOptionFn {
+	return func(o *Options) {
+		o.imports = append(o.imports, ast.ImportExpr{Path: path, Alias: alias})
+	}
+}
+
+// WithMaxSteps limits the number of expressions that may be evaluated by an execution.
+func WithMaxSteps(n int) ExecuteOptionFn {
+	return func(o *Options) {
+		o.MaxSteps = n
+	}
+}
+
+// WithMaxDepth limits the depth that expressions may be nested, including through recursion.
+func WithMaxDepth(n int) ExecuteOptionFn {
+	return func(o *Options) {
+		o.MaxDepth = n
+	}
+}
+
+// WithMaxOutputSize limits the number of values, including nested values, in the result of an execution.
+func WithMaxOutputSize(n int) ExecuteOptionFn {
+	return func(o *Options) {
+		o.MaxOutputSize = n
+	}
+}
+
+// WithTracer sets a tracer that is called before and after each expression is evaluated.
+func WithTracer(t Tracer) ExecuteOptionFn {
+	return func(o *Options) {
+		o.Tracer = t
+	}
+}
+
 // WithUnstable allows access to potentially unstable features.
 func WithUnstable() ExecuteOptionFn {
 	return func(o *Options) {
@@ -55,16 +133,31 @@ func WithoutUnstable() ExecuteOptionFn {
 	}
 }
 
-// withKeyVar sets the $key variable to the given value and returns a function
-// that restores the previous value (or deletes it if it didn't exist).
-func withKeyVar(options *Options, key *model.Value) func() {
-	prev, had := options.Vars["key"]
-	options.Vars["key"] = key
-	return func() {
-		if had {
-			options.Vars["key"] = prev
-		} else {
-			delete(options.Vars, "key")
-		}
-	}
+// newExecution returns a copy of the options to be used by a single execution.
+// The copy has its own root scope and usage, so that the options given
+// by the caller are never modified and can be shared between concurrent executions.
+func (o *Options) newExecution() *Options {
+	c := *o
+	c.scope = newScope(nil, maps.Clone(o.Vars))
+	c.paths = nil
+	c.usage = &usage{}
+	return &c
+}
+
+// withScope returns a copy of the options that executes within a new child scope
+// containing the given variables.
+func (o *Options) withScope(parent *scope, vars map[string]*model.Value) *Options {
+	c := *o
+	c.scope = newScope(parent, vars)
+	return &c
+}
+
+// withKeyVar returns a copy of the options with the $key variable set to the given value.
+func withKeyVar(options *Options, key *model.Value) *Options {
+	return options.withScope(options.scope, map[string]*model.Value{"key": key})
+}
+
+// funcNames returns the names of all functions that may be called, including user defined functions.
+func (o *Options) funcNames() []string {
+	return append(slices.Collect(maps.Keys(o.Funcs)), o.scope.defNames()...)
 }
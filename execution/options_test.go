@@ -1,9 +1,12 @@
 package execution_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	_ "github.com/tomwright/dasel/v3/parsing/json"
 )
 
 func TestOptions(t *testing.T) {
@@ -38,4 +41,98 @@ func TestFuncCollection(t *testing.T) {
 			t.Error("expected len to be deleted")
 		}
 	})
+
+	t.Run("CheckCall", func(t *testing.T) {
+		fc := execution.DefaultFuncCollection
+		if ok, err := fc.CheckCall("len", 1); !ok || err != nil {
+			t.Errorf("expected len to accept 1 argument, got %v, %v", ok, err)
+		}
+		if ok, err := fc.CheckCall("len", 2); !ok || err == nil {
+			t.Errorf("expected len to reject 2 arguments, got %v, %v", ok, err)
+		}
+		if ok, err := fc.CheckCall("len", -1); !ok || err != nil {
+			t.Errorf("expected len to exist when the number of arguments is unknown, got %v, %v", ok, err)
+		}
+		if ok, _ := fc.CheckCall("nope", 0); ok {
+			t.Error("expected nope to not exist")
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		fc := execution.DefaultFuncCollection.Copy()
+		if f, ok := fc.Func("len"); !ok || f.Name() != "len" || f.Doc().Description == "" {
+			t.Errorf("expected len to be documented, got %v, %v", f, ok)
+		}
+
+		fc["custom"] = func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+			return data, nil
+		}
+		if f, ok := fc.Func("custom"); !ok || f.Name() != "custom" {
+			t.Errorf("expected custom to be found, got %v, %v", f, ok)
+		}
+		if ok, err := fc.CheckCall("custom", 3); !ok || err != nil {
+			t.Errorf("expected custom to accept any arguments, got %v, %v", ok, err)
+		}
+	})
+
+	t.Run("Func is read from the collection", func(t *testing.T) {
+		fn := func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+			return data, nil
+		}
+		fc := execution.NewFuncCollection(execution.NewFunc("len", fn, execution.ValidateArgsExactly(2)))
+		if _, err := fc.CheckCall("len", 0); err == nil {
+			t.Error("expected the new collection to require 2 arguments")
+		}
+		if _, err := execution.DefaultFuncCollection.CheckCall("len", 1); err != nil {
+			t.Errorf("expected the default collection to be unchanged, got %v", err)
+		}
+		if f, _ := execution.DefaultFuncCollection.Func("len"); f.Doc().Description == "" {
+			t.Error("expected the default len to keep its documentation")
+		}
+
+		fc = execution.DefaultFuncCollection.Copy()
+		fc["len"] = fn
+		if f, _ := fc.Func("len"); f.Doc().Description != "" {
+			t.Error("expected an overwritten handler to have no documentation")
+		}
+		if _, err := fc.CheckCall("len", 5); err != nil {
+			t.Errorf("expected an overwritten handler to accept any arguments, got %v", err)
+		}
+	})
+
+	t.Run("Suggest", func(t *testing.T) {
+		fc := execution.DefaultFuncCollection
+		if got := fc.Suggest("toUpperr"); got != "toUpper" {
+			t.Errorf("expected toUpper, got %q", got)
+		}
+		if got := fc.Suggest("nothingLikeThis"); got != "" {
+			t.Errorf("expected no suggestion, got %q", got)
+		}
+	})
+}
+
+func TestFuncDocs(t *testing.T) {
+	for _, f := range execution.DefaultFuncCollection.List() {
+		t.Run(f.Name(), func(t *testing.T) {
+			doc := f.Doc()
+			if doc.Description == "" {
+				t.Error("expected a description")
+			}
+			if doc.Returns == "" {
+				t.Error("expected a return type")
+			}
+			for _, example := range doc.Examples {
+				t.Run(example.Selector, func(t *testing.T) {
+					out, err := execution.ExecuteSelector(context.Background(), example.Output, model.NewNullValue(), execution.NewOptions())
+					if err != nil {
+						t.Fatalf("unexpected error executing output: %v", err)
+					}
+					testCase{
+						s:   example.Selector,
+						out: out,
+					}.run(t)
+				})
+			}
+		})
+	}
 }
@@ -0,0 +1,438 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// ErrPatchTestFailed is returned when a JSON Patch test operation does not match.
+var ErrPatchTestFailed = errors.New("test operation failed")
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to a copy of the document and returns the result.
+// The patch must be a slice of operation maps. The given document is never modified,
+// so if any operation fails no changes are visible.
+func ApplyJSONPatch(doc *model.Value, patch *model.Value) (*model.Value, error) {
+	if !patch.IsSlice() {
+		return nil, fmt.Errorf("json patch must be an array of operations, got %s", patch.Type())
+	}
+
+	docCopy, err := copyValue(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error copying document: %w", err)
+	}
+	p := &jsonPatcher{doc: docCopy}
+
+	if err := patch.RangeSlice(func(i int, op *model.Value) error {
+		if err := p.apply(op); err != nil {
+			return fmt.Errorf("json patch operation %d: %w", i, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return p.doc, nil
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch to a copy of the document and returns the result.
+// The given document is never modified.
+func ApplyMergePatch(doc *model.Value, patch *model.Value) (*model.Value, error) {
+	docCopy, err := copyValue(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error copying document: %w", err)
+	}
+	return mergePatch(docCopy, patch)
+}
+
+func mergePatch(target *model.Value, patch *model.Value) (*model.Value, error) {
+	if !patch.IsMap() {
+		return copyValue(patch)
+	}
+
+	if target == nil || !target.IsMap() {
+		target = model.NewMapValue()
+	}
+
+	if err := patch.RangeMap(func(key string, patchValue *model.Value) error {
+		exists, err := target.MapKeyExists(key)
+		if err != nil {
+			return err
+		}
+
+		if patchValue.IsNull() {
+			if exists {
+				return target.DeleteMapKey(key)
+			}
+			return nil
+		}
+
+		var existing *model.Value
+		if exists {
+			existing, err = target.GetMapKey(key)
+			if err != nil {
+				return err
+			}
+		}
+		merged, err := mergePatch(existing, patchValue)
+		if err != nil {
+			return err
+		}
+		return target.SetMapKey(key, merged)
+	}); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+type jsonPatcher struct {
+	doc *model.Value
+}
+
+func (p *jsonPatcher) apply(op *model.Value) error {
+	if !op.IsMap() {
+		return fmt.Errorf("operation must be an object, got %s", op.Type())
+	}
+
+	opName, err := patchOpString(op, "op")
+	if err != nil {
+		return err
+	}
+	pathStr, err := patchOpString(op, "path")
+	if err != nil {
+		return err
+	}
+	path, err := parseJSONPointer(pathStr)
+	if err != nil {
+		return err
+	}
+
+	switch opName {
+	case "add", "replace", "test":
+		exists, err := op.MapKeyExists("value")
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%s operation requires a value", opName)
+		}
+		value, err := op.GetMapKey("value")
+		if err != nil {
+			return err
+		}
+		value, err = copyValue(value)
+		if err != nil {
+			return err
+		}
+		switch opName {
+		case "add":
+			return p.add(path, value)
+		case "replace":
+			return p.replace(path, value)
+		default:
+			return p.test(pathStr, path, value)
+		}
+	case "remove":
+		_, err := p.remove(path)
+		return err
+	case "move", "copy":
+		fromStr, err := patchOpString(op, "from")
+		if err != nil {
+			return err
+		}
+		from, err := parseJSONPointer(fromStr)
+		if err != nil {
+			return err
+		}
+		if opName == "copy" {
+			value, err := p.get(from)
+			if err != nil {
+				return err
+			}
+			value, err = copyValue(value)
+			if err != nil {
+				return err
+			}
+			return p.add(path, value)
+		}
+		if len(path) > len(from) && strings.HasPrefix(pathStr, fromStr+"/") {
+			return fmt.Errorf("cannot move %q into one of its children", fromStr)
+		}
+		value, err := p.remove(from)
+		if err != nil {
+			return err
+		}
+		return p.add(path, value)
+	default:
+		return fmt.Errorf("unknown operation %q", opName)
+	}
+}
+
+func (p *jsonPatcher) get(path []string) (*model.Value, error) {
+	cur := p.doc
+	for _, token := range path {
+		switch {
+		case cur.IsMap():
+			next, err := cur.GetMapKey(token)
+			if err != nil {
+				return nil, err
+			}
+			cur = next
+		case cur.IsSlice():
+			l, err := cur.SliceLen()
+			if err != nil {
+				return nil, err
+			}
+			i, err := parseJSONPointerIndex(token, l-1)
+			if err != nil {
+				return nil, err
+			}
+			next, err := cur.GetSliceIndex(i)
+			if err != nil {
+				return nil, err
+			}
+			cur = next
+		default:
+			return nil, fmt.Errorf("cannot read %q from %s", token, cur.Type())
+		}
+	}
+	return cur, nil
+}
+
+func (p *jsonPatcher) add(path []string, value *model.Value) error {
+	if len(path) == 0 {
+		p.doc = value
+		return nil
+	}
+	parent, err := p.get(path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	token := path[len(path)-1]
+
+	switch {
+	case parent.IsMap():
+		return parent.SetMapKey(token, value)
+	case parent.IsSlice():
+		l, err := parent.SliceLen()
+		if err != nil {
+			return err
+		}
+		if token == "-" {
+			return parent.Append(value)
+		}
+		i, err := parseJSONPointerIndex(token, l)
+		if err != nil {
+			return err
+		}
+		if err := parent.Append(value); err != nil {
+			return err
+		}
+		for j := l; j > i; j-- {
+			prev, err := parent.GetSliceIndex(j - 1)
+			if err != nil {
+				return err
+			}
+			if err := parent.SetSliceIndex(j, prev); err != nil {
+				return err
+			}
+		}
+		return parent.SetSliceIndex(i, value)
+	default:
+		return fmt.Errorf("cannot add %q to %s", token, parent.Type())
+	}
+}
+
+func (p *jsonPatcher) remove(path []string) (*model.Value, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the root document")
+	}
+	value, err := p.get(path)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := p.get(path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+
+	switch {
+	case parent.IsMap():
+		return value, parent.DeleteMapKey(token)
+	default:
+		l, err := parent.SliceLen()
+		if err != nil {
+			return nil, err
+		}
+		i, err := parseJSONPointerIndex(token, l-1)
+		if err != nil {
+			return nil, err
+		}
+		return value, parent.DeleteSliceIndex(i)
+	}
+}
+
+func (p *jsonPatcher) replace(path []string, value *model.Value) error {
+	if len(path) == 0 {
+		p.doc = value
+		return nil
+	}
+	if _, err := p.get(path); err != nil {
+		return err
+	}
+	parent, err := p.get(path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	token := path[len(path)-1]
+
+	if parent.IsMap() {
+		return parent.SetMapKey(token, value)
+	}
+	l, err := parent.SliceLen()
+	if err != nil {
+		return err
+	}
+	i, err := parseJSONPointerIndex(token, l-1)
+	if err != nil {
+		return err
+	}
+	return parent.SetSliceIndex(i, value)
+}
+
+func (p *jsonPatcher) test(pathStr string, path []string, value *model.Value) error {
+	got, err := p.get(path)
+	if err != nil {
+		return err
+	}
+	equal, err := valuesEqual(got, value)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return fmt.Errorf("%w: value at %q does not match", ErrPatchTestFailed, pathStr)
+	}
+	return nil
+}
+
+func patchOpString(op *model.Value, key string) (string, error) {
+	v, err := op.GetMapKey(key)
+	if err != nil {
+		return "", fmt.Errorf("operation is missing %q", key)
+	}
+	s, err := v.StringValue()
+	if err != nil {
+		return "", fmt.Errorf("operation %q must be a string", key)
+	}
+	return s, nil
+}
+
+// parseJSONPointer parses an RFC 6901 JSON Pointer into its unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer %q: must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// parseJSONPointerIndex parses an array index reference token, which must be between 0 and max.
+func parseJSONPointerIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > max {
+		return 0, model.SliceIndexOutOfRange{Index: i}
+	}
+	return i, nil
+}
+
+// valuesEqual compares two values structurally. Numbers are compared by value regardless of type.
+func valuesEqual(a *model.Value, b *model.Value) (bool, error) {
+	isNumber := func(v *model.Value) bool {
+		return v.IsInt() || v.IsFloat()
+	}
+	if isNumber(a) && isNumber(b) {
+		eq, err := a.Equal(b)
+		if err != nil {
+			return false, err
+		}
+		return eq.BoolValue()
+	}
+	return a.EqualTypeValue(b)
+}
+
+// copyValue returns a deep copy of the given value, including metadata.
+func copyValue(v *model.Value) (*model.Value, error) {
+	var res *model.Value
+	switch v.Type() {
+	case model.TypeMap:
+		res = model.NewMapValue()
+		if err := v.RangeMap(func(key string, item *model.Value) error {
+			itemCopy, err := copyValue(item)
+			if err != nil {
+				return err
+			}
+			return res.SetMapKey(key, itemCopy)
+		}); err != nil {
+			return nil, err
+		}
+	case model.TypeSlice:
+		res = model.NewSliceValue()
+		if err := v.RangeSlice(func(_ int, item *model.Value) error {
+			itemCopy, err := copyValue(item)
+			if err != nil {
+				return err
+			}
+			return res.Append(itemCopy)
+		}); err != nil {
+			return nil, err
+		}
+	case model.TypeString:
+		s, err := v.StringValue()
+		if err != nil {
+			return nil, err
+		}
+		res = model.NewStringValue(s)
+	case model.TypeInt:
+		i, err := v.IntValue()
+		if err != nil {
+			return nil, err
+		}
+		res = model.NewIntValue(i)
+	case model.TypeFloat:
+		f, err := v.FloatValue()
+		if err != nil {
+			return nil, err
+		}
+		res = model.NewFloatValue(f)
+	case model.TypeBool:
+		b, err := v.BoolValue()
+		if err != nil {
+			return nil, err
+		}
+		res = model.NewBoolValue(b)
+	case model.TypeNull:
+		res = model.NewNullValue()
+	default:
+		return nil, fmt.Errorf("copy not supported for type: %s", v.Type())
+	}
+	res.Metadata = maps.Clone(v.Metadata)
+	return res, nil
+}
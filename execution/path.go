@@ -0,0 +1,98 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// trackPath records that child was selected from parent using the given map key or slice index,
+// so that its location within the input can be given by $path. It returns child.
+// Paths are only recorded when the program uses $path.
+// A child that already has a path keeps it when the parent's location is unknown,
+// e.g. when ranging over an array of results built by the selector.
+func trackPath(options *Options, parent *model.Value, child *model.Value, segment any) *model.Value {
+	if options.paths == nil {
+		return child
+	}
+	parentPath, parentKnown := options.paths[parent]
+	if _, childKnown := options.paths[child]; childKnown && !parentKnown {
+		return child
+	}
+	path := make([]any, len(parentPath), len(parentPath)+1)
+	copy(path, parentPath)
+	options.paths[child] = append(path, segment)
+	return child
+}
+
+// usesPath returns true if the expression may read $path, and so paths must be tracked.
+// Imports are assumed to use it, since their contents are not known until they are executed.
+func usesPath(expr ast.Expr) bool {
+	var found bool
+	ast.Inspect(expr, func(e ast.Expr) bool {
+		switch e := e.(type) {
+		case ast.VariableExpr:
+			found = found || e.Name == "path"
+		case ast.ImportExpr:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// valuePath returns the location of v within the input, or an empty path if it is unknown.
+func valuePath(options *Options, v *model.Value) []any {
+	return options.paths[v]
+}
+
+// pathValue returns the path as an array of map keys and slice indexes.
+func pathValue(path []any) (*model.Value, error) {
+	res := model.NewSliceValue()
+	for _, segment := range path {
+		var v *model.Value
+		switch s := segment.(type) {
+		case string:
+			v = model.NewStringValue(s)
+		case int:
+			v = model.NewIntValue(int64(s))
+		default:
+			return nil, fmt.Errorf("unexpected path segment type %T", segment)
+		}
+		if err := res.Append(v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// pathFromValue parses an array of map keys and slice indexes.
+func pathFromValue(v *model.Value) ([]any, error) {
+	if !v.IsSlice() {
+		return nil, fmt.Errorf("expected path to be an array, got %s", v.Type())
+	}
+	var path []any
+	if err := v.RangeSlice(func(i int, segment *model.Value) error {
+		switch {
+		case segment.IsString():
+			s, err := segment.StringValue()
+			if err != nil {
+				return err
+			}
+			path = append(path, s)
+		case segment.IsInt():
+			index, err := segment.IntValue()
+			if err != nil {
+				return err
+			}
+			path = append(path, int(index))
+		default:
+			return fmt.Errorf("expected path segment %d to be a string or int, got %s", i, segment.Type())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
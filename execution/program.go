@@ -0,0 +1,98 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// Program is a selector that has been parsed and compiled ahead of time.
+// A Program can be executed many times, including concurrently.
+type Program struct {
+	expr     ast.Expr
+	executor expressionExecutor
+	// trackPaths is true if the program uses $path, so the location of each selected value must be recorded.
+	trackPaths bool
+	// unstable is true if the program uses unstable features.
+	unstable bool
+	// modules holds the files imported by the program, which are read and compiled when first used.
+	modules *moduleCache
+}
+
+// CompileSelector parses the selector and compiles the resulting AST.
+func CompileSelector(selectorStr string) (*Program, error) {
+	if selectorStr == "" {
+		return CompileAST(nil)
+	}
+
+	expr, err := selector.Parse(selectorStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing selector: %w", err)
+	}
+
+	program, err := CompileAST(expr)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling selector: %w", err)
+	}
+	return program, nil
+}
+
+// CompileAST compiles the given AST.
+func CompileAST(expr ast.Expr) (*Program, error) {
+	executor, err := compileAST(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		expr:       expr,
+		executor:   executor,
+		trackPaths: usesPath(expr),
+		unstable:   usesUnstable(expr),
+		modules:    newModuleCache(),
+	}, nil
+}
+
+// errUnstable is returned when a selector uses unstable features without them being enabled.
+var errUnstable = errors.New("unstable features are not enabled. to enable them use --unstable")
+
+// usesUnstable returns true if the expression uses unstable features.
+func usesUnstable(expr ast.Expr) bool {
+	var found bool
+	ast.Inspect(expr, func(e ast.Expr) bool {
+		found = found || selector.IsUnstable(e)
+		return !found
+	})
+	return found
+}
+
+// Execute executes the program with the given input.
+func (p *Program) Execute(ctx context.Context, value *model.Value, options *Options) (*model.Value, error) {
+	if p.expr == nil {
+		return value, nil
+	}
+	if p.unstable && !options.Unstable {
+		return nil, errUnstable
+	}
+
+	options = options.newExecution()
+	options.modules = p.modules
+	if p.trackPaths || len(options.imports) > 0 {
+		options.paths = map[*model.Value][]any{}
+	}
+	if err := loadImports(ctx, options); err != nil {
+		return nil, err
+	}
+
+	res, err := p.executor(ctx, options, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOutputSize(options, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package execution_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestProgram(t *testing.T) {
+	t.Run("execute many times", func(t *testing.T) {
+		program, err := execution.CompileSelector(`def double(x) = $x * 2; double(value)`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := int64(0); i < 3; i++ {
+			in := model.NewMapValue()
+			if err := in.SetMapKey("value", model.NewIntValue(i)); err != nil {
+				t.Fatal(err)
+			}
+			res, err := program.Execute(t.Context(), in, execution.NewOptions())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := res.IntValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != i*2 {
+				t.Errorf("expected %d, got %d", i*2, got)
+			}
+		}
+	})
+
+	t.Run("execute concurrently", func(t *testing.T) {
+		program, err := execution.CompileSelector(`items.filter($this > 1).map($this + $offset)`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var wg sync.WaitGroup
+		for i := int64(0); i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				items := model.NewSliceValue()
+				for _, v := range []int64{1, 2, 3} {
+					if err := items.Append(model.NewIntValue(v)); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+				in := model.NewMapValue()
+				if err := in.SetMapKey("items", items); err != nil {
+					t.Error(err)
+					return
+				}
+				opts := execution.NewOptions(execution.WithVariable("offset", model.NewIntValue(i)))
+				res, err := program.Execute(t.Context(), in, opts)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				got, err := res.GoValue()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				exp := []any{2 + i, 3 + i}
+				if gotSlice, ok := got.([]any); !ok || len(gotSlice) != 2 || gotSlice[0] != exp[0] || gotSlice[1] != exp[1] {
+					t.Errorf("expected %v, got %v", exp, got)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("empty selector", func(t *testing.T) {
+		program, err := execution.CompileSelector("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		in := model.NewStringValue("x")
+		res, err := program.Execute(t.Context(), in, execution.NewOptions())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res != in {
+			t.Errorf("expected input to be returned")
+		}
+	})
+
+	t.Run("unstable features are rejected before execution", func(t *testing.T) {
+		program, err := execution.CompileSelector(`called(); branch(1, 2)`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var called bool
+		funcs := execution.DefaultFuncCollection.Copy().Register(
+			execution.NewFunc("called", func(ctx context.Context, data *model.Value, args model.Values) (*model.Value, error) {
+				called = true
+				return data, nil
+			}, execution.ValidateArgsExactly(0)),
+		)
+		if _, err := program.Execute(t.Context(), model.NewNullValue(), execution.NewOptions(execution.WithFuncs(funcs))); err == nil {
+			t.Fatal("expected error")
+		}
+		if called {
+			t.Error("expected the program not to be executed")
+		}
+		if _, err := program.Execute(t.Context(), model.NewNullValue(), execution.NewOptions(execution.WithFuncs(funcs), execution.WithUnstable())); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := execution.CompileSelector(`true ?`); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
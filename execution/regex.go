@@ -0,0 +1,72 @@
+package execution
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// regexMetadataKey is the metadata key used to attach the compiled pattern of a regex literal to its value.
+const regexMetadataKey = "regex"
+
+// regexValue returns the string value of a regex literal, with the compiled pattern attached as metadata.
+func regexValue(re *regexp.Regexp) *model.Value {
+	v := model.NewStringValue(re.String())
+	v.SetMetadataValue(regexMetadataKey, re)
+	return v
+}
+
+// regexFromValue returns the compiled pattern of a regex literal, or compiles the pattern if given as a string.
+func regexFromValue(v *model.Value) (*regexp.Regexp, error) {
+	if re, ok := v.MetadataValue(regexMetadataKey); ok {
+		if re, ok := re.(*regexp.Regexp); ok {
+			return re, nil
+		}
+	}
+	pattern, err := v.StringValue()
+	if err != nil {
+		return nil, fmt.Errorf("expected a regex pattern: %w", err)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile regexp pattern: %w", err)
+	}
+	return re, nil
+}
+
+// regexFuncArgs returns the input string, pattern and remaining arguments of a regex function.
+// The input string is taken from data unless given as an additional first argument.
+// argCount is the number of arguments expected when the input comes from data.
+func regexFuncArgs(data *model.Value, args model.Values, argCount int) (string, *regexp.Regexp, model.Values, error) {
+	input := data
+	if len(args) > argCount {
+		input = args[0]
+		args = args[1:]
+	}
+	s, err := input.StringValue()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("expected input to be a string: %w", err)
+	}
+	re, err := regexFromValue(args[0])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return s, re, args[1:], nil
+}
+
+// regexSubmatchValue returns the full match followed by each group as a slice.
+// Groups that did not participate in the match are null.
+func regexSubmatchValue(s string, loc []int) (*model.Value, error) {
+	res := model.NewSliceValue()
+	for i := 0; i < len(loc); i += 2 {
+		group := model.NewNullValue()
+		if loc[i] >= 0 {
+			group = model.NewStringValue(s[loc[i]:loc[i+1]])
+		}
+		if err := res.Append(group); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
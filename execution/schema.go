@@ -0,0 +1,705 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tomwright/dasel/v3/internal/valuestr"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector"
+)
+
+// maxSchemaDepth limits how deeply schemas may be nested or referenced, to protect against $ref cycles.
+const maxSchemaDepth = 512
+
+// SchemaError is a single JSON Schema validation failure.
+type SchemaError struct {
+	// Path is the selector path to the failing value.
+	Path string
+	// Keyword is the schema keyword that failed.
+	Keyword string
+	// Message describes the failure.
+	Message string
+}
+
+// Error returns the error message.
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ErrSchemaValidation is returned when a value does not match a JSON Schema.
+type ErrSchemaValidation struct {
+	Errors []SchemaError
+}
+
+// Error returns the error message.
+func (e ErrSchemaValidation) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = err.Error()
+	}
+	return "schema validation failed:\n" + strings.Join(lines, "\n")
+}
+
+// ValidateSchema validates the value against the given JSON Schema (draft 2020-12).
+// If the value is invalid an ErrSchemaValidation is returned containing every failure.
+// References are resolved within the schema document.
+func ValidateSchema(value *model.Value, schema *model.Value) error {
+	v := &schemaValidator{
+		root:    schema,
+		regexps: map[string]*regexp.Regexp{},
+	}
+	errs, err := v.validate("", value, schema, 0)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return ErrSchemaValidation{Errors: errs}
+	}
+	return nil
+}
+
+type schemaValidator struct {
+	root    *model.Value
+	regexps map[string]*regexp.Regexp
+}
+
+// validate returns the validation failures for value against schema.
+// A non-nil error is returned if the schema itself is invalid.
+func (v *schemaValidator) validate(path string, value *model.Value, schema *model.Value, depth int) ([]SchemaError, error) {
+	if depth > maxSchemaDepth {
+		return nil, fmt.Errorf("schema exceeds max depth of %d", maxSchemaDepth)
+	}
+
+	if schema.IsBool() {
+		allowed, err := schema.BoolValue()
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			return nil, nil
+		}
+		return []SchemaError{{Path: selector.PathRoot(path), Keyword: "false", Message: "no value is allowed"}}, nil
+	}
+	if !schema.IsMap() {
+		return nil, fmt.Errorf("invalid schema at %s: expected object or boolean, got %s", selector.PathRoot(path), schema.Type())
+	}
+
+	var errs []SchemaError
+	fail := func(keyword string, format string, args ...any) {
+		errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	keywords, err := schema.MapKeyValues()
+	if err != nil {
+		return nil, err
+	}
+	kw := make(map[string]*model.Value, len(keywords))
+	for _, k := range keywords {
+		kw[k.Key] = k.Value
+	}
+
+	for _, k := range keywords {
+		switch k.Key {
+		case "$ref":
+			ref, err := k.Value.StringValue()
+			if err != nil {
+				return nil, fmt.Errorf("invalid $ref: %w", err)
+			}
+			refSchema, err := v.resolveRef(ref)
+			if err != nil {
+				return nil, err
+			}
+			refErrs, err := v.validate(path, value, refSchema, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, refErrs...)
+
+		case "type":
+			var types []string
+			if k.Value.IsSlice() {
+				if err := k.Value.RangeSlice(func(_ int, t *model.Value) error {
+					s, err := t.StringValue()
+					types = append(types, s)
+					return err
+				}); err != nil {
+					return nil, fmt.Errorf("invalid type: %w", err)
+				}
+			} else {
+				s, err := k.Value.StringValue()
+				if err != nil {
+					return nil, fmt.Errorf("invalid type: %w", err)
+				}
+				types = []string{s}
+			}
+			if !slices.ContainsFunc(types, func(t string) bool { return schemaTypeMatches(t, value) }) {
+				fail("type", "expected %s, got %s", strings.Join(types, " or "), schemaTypeOf(value))
+			}
+
+		case "enum":
+			found := false
+			if err := k.Value.RangeSlice(func(_ int, option *model.Value) error {
+				if found {
+					return nil
+				}
+				eq, err := valuesEqual(value, option)
+				found = eq
+				return err
+			}); err != nil {
+				return nil, fmt.Errorf("invalid enum: %w", err)
+			}
+			if !found {
+				fail("enum", "value %s is not one of %s", valuestr.Compact(value), valuestr.Compact(k.Value))
+			}
+
+		case "const":
+			eq, err := valuesEqual(value, k.Value)
+			if err != nil {
+				return nil, err
+			}
+			if !eq {
+				fail("const", "expected %s, got %s", valuestr.Compact(k.Value), valuestr.Compact(value))
+			}
+
+		case "allOf", "anyOf", "oneOf":
+			matches := 0
+			var subErrs []SchemaError
+			if err := k.Value.RangeSlice(func(_ int, sub *model.Value) error {
+				e, err := v.validate(path, value, sub, depth+1)
+				if err != nil {
+					return err
+				}
+				if len(e) == 0 {
+					matches++
+				}
+				subErrs = append(subErrs, e...)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			switch {
+			case k.Key == "allOf":
+				errs = append(errs, subErrs...)
+			case k.Key == "anyOf" && matches == 0:
+				fail("anyOf", "value does not match any of the schemas")
+				errs = append(errs, subErrs...)
+			case k.Key == "oneOf" && matches != 1:
+				fail("oneOf", "value must match exactly one schema, matched %d", matches)
+			}
+
+		case "not":
+			e, err := v.validate(path, value, k.Value, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if len(e) == 0 {
+				fail("not", "value must not match the schema")
+			}
+
+		case "if":
+			e, err := v.validate(path, value, k.Value, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			branch := kw["then"]
+			if len(e) > 0 {
+				branch = kw["else"]
+			}
+			if branch != nil {
+				e, err := v.validate(path, value, branch, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				errs = append(errs, e...)
+			}
+		}
+	}
+
+	var valueErrs []SchemaError
+	switch {
+	case value.IsString():
+		valueErrs, err = v.validateString(path, value, kw)
+	case value.IsInt() || value.IsFloat():
+		valueErrs, err = v.validateNumber(path, value, kw)
+	case value.IsMap():
+		valueErrs, err = v.validateObject(path, value, kw, depth)
+	case value.IsSlice():
+		valueErrs, err = v.validateArray(path, value, kw, depth)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(errs, valueErrs...), nil
+}
+
+func (v *schemaValidator) validateString(path string, value *model.Value, kw map[string]*model.Value) ([]SchemaError, error) {
+	var errs []SchemaError
+	s, err := value.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	length := utf8.RuneCountInString(s)
+
+	if min, ok, err := schemaInt(kw, "minLength"); err != nil {
+		return nil, err
+	} else if ok && length < min {
+		errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: "minLength", Message: fmt.Sprintf("length must be at least %d, got %d", min, length)})
+	}
+	if max, ok, err := schemaInt(kw, "maxLength"); err != nil {
+		return nil, err
+	} else if ok && length > max {
+		errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: "maxLength", Message: fmt.Sprintf("length must be at most %d, got %d", max, length)})
+	}
+	if pattern, ok := kw["pattern"]; ok {
+		p, err := pattern.StringValue()
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		re, err := v.regexp(p)
+		if err != nil {
+			return nil, err
+		}
+		if !re.MatchString(s) {
+			errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: "pattern", Message: fmt.Sprintf("%q does not match pattern %q", s, p)})
+		}
+	}
+	return errs, nil
+}
+
+func (v *schemaValidator) validateNumber(path string, value *model.Value, kw map[string]*model.Value) ([]SchemaError, error) {
+	var errs []SchemaError
+	n, _ := schemaNumber(value)
+
+	checks := []struct {
+		keyword string
+		failed  func(limit float64) bool
+		message string
+	}{
+		{"minimum", func(limit float64) bool { return n < limit }, "must be >= %v"},
+		{"maximum", func(limit float64) bool { return n > limit }, "must be <= %v"},
+		{"exclusiveMinimum", func(limit float64) bool { return n <= limit }, "must be > %v"},
+		{"exclusiveMaximum", func(limit float64) bool { return n >= limit }, "must be < %v"},
+		{"multipleOf", func(limit float64) bool {
+			q := n / limit
+			return math.Abs(q-math.Round(q)) > 1e-9
+		}, "must be a multiple of %v"},
+	}
+	for _, check := range checks {
+		limitValue, ok := kw[check.keyword]
+		if !ok {
+			continue
+		}
+		limit, ok := schemaNumber(limitValue)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: expected number, got %s", check.keyword, limitValue.Type())
+		}
+		if check.failed(limit) {
+			errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: check.keyword, Message: fmt.Sprintf("value %v "+check.message, n, limit)})
+		}
+	}
+	return errs, nil
+}
+
+func (v *schemaValidator) validateObject(path string, value *model.Value, kw map[string]*model.Value, depth int) ([]SchemaError, error) {
+	var errs []SchemaError
+	fail := func(keyword string, format string, args ...any) {
+		errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if required, ok := kw["required"]; ok {
+		if err := required.RangeSlice(func(_ int, key *model.Value) error {
+			k, err := key.StringValue()
+			if err != nil {
+				return err
+			}
+			exists, err := value.MapKeyExists(k)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				fail("required", "missing required property %q", k)
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("invalid required: %w", err)
+		}
+	}
+
+	count, err := value.MapLen()
+	if err != nil {
+		return nil, err
+	}
+	if min, ok, err := schemaInt(kw, "minProperties"); err != nil {
+		return nil, err
+	} else if ok && count < min {
+		fail("minProperties", "must have at least %d properties, got %d", min, count)
+	}
+	if max, ok, err := schemaInt(kw, "maxProperties"); err != nil {
+		return nil, err
+	} else if ok && count > max {
+		fail("maxProperties", "must have at most %d properties, got %d", max, count)
+	}
+
+	var patterns []*regexp.Regexp
+	var patternSchemas []*model.Value
+	if patternProperties, ok := kw["patternProperties"]; ok {
+		if err := patternProperties.RangeMap(func(p string, sub *model.Value) error {
+			re, err := v.regexp(p)
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, re)
+			patternSchemas = append(patternSchemas, sub)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	properties := kw["properties"]
+	additional := kw["additionalProperties"]
+	propertyNames := kw["propertyNames"]
+
+	if err := value.RangeMap(func(key string, item *model.Value) error {
+		itemPath := selector.PathKey(path, key)
+		matched := false
+
+		if properties != nil {
+			exists, err := properties.MapKeyExists(key)
+			if err != nil {
+				return err
+			}
+			if exists {
+				matched = true
+				sub, err := properties.GetMapKey(key)
+				if err != nil {
+					return err
+				}
+				e, err := v.validate(itemPath, item, sub, depth+1)
+				if err != nil {
+					return err
+				}
+				errs = append(errs, e...)
+			}
+		}
+
+		for i, re := range patterns {
+			if !re.MatchString(key) {
+				continue
+			}
+			matched = true
+			e, err := v.validate(itemPath, item, patternSchemas[i], depth+1)
+			if err != nil {
+				return err
+			}
+			errs = append(errs, e...)
+		}
+
+		if !matched && additional != nil {
+			if additional.IsBool() {
+				allowed, err := additional.BoolValue()
+				if err != nil {
+					return err
+				}
+				if !allowed {
+					fail("additionalProperties", "additional property %q is not allowed", key)
+				}
+			} else {
+				e, err := v.validate(itemPath, item, additional, depth+1)
+				if err != nil {
+					return err
+				}
+				errs = append(errs, e...)
+			}
+		}
+
+		if propertyNames != nil {
+			e, err := v.validate(itemPath, model.NewStringValue(key), propertyNames, depth+1)
+			if err != nil {
+				return err
+			}
+			errs = append(errs, e...)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (v *schemaValidator) validateArray(path string, value *model.Value, kw map[string]*model.Value, depth int) ([]SchemaError, error) {
+	var errs []SchemaError
+	fail := func(keyword string, format string, args ...any) {
+		errs = append(errs, SchemaError{Path: selector.PathRoot(path), Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length, err := value.SliceLen()
+	if err != nil {
+		return nil, err
+	}
+	if min, ok, err := schemaInt(kw, "minItems"); err != nil {
+		return nil, err
+	} else if ok && length < min {
+		fail("minItems", "must have at least %d items, got %d", min, length)
+	}
+	if max, ok, err := schemaInt(kw, "maxItems"); err != nil {
+		return nil, err
+	} else if ok && length > max {
+		fail("maxItems", "must have at most %d items, got %d", max, length)
+	}
+
+	prefixLen := 0
+	var prefixItems *model.Value
+	if p, ok := kw["prefixItems"]; ok {
+		prefixItems = p
+		prefixLen, err = p.SliceLen()
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefixItems: %w", err)
+		}
+	}
+	items := kw["items"]
+	contains := kw["contains"]
+	containsCount := 0
+
+	var seen []*model.Value
+	uniqueItems := false
+	if u, ok := kw["uniqueItems"]; ok {
+		uniqueItems, err = u.BoolValue()
+		if err != nil {
+			return nil, fmt.Errorf("invalid uniqueItems: %w", err)
+		}
+	}
+
+	if err := value.RangeSlice(func(i int, item *model.Value) error {
+		itemPath := selector.PathIndex(path, i)
+
+		var sub *model.Value
+		if i < prefixLen {
+			s, err := prefixItems.GetSliceIndex(i)
+			if err != nil {
+				return err
+			}
+			sub = s
+		} else if items != nil {
+			sub = items
+		}
+		if sub != nil {
+			e, err := v.validate(itemPath, item, sub, depth+1)
+			if err != nil {
+				return err
+			}
+			errs = append(errs, e...)
+		}
+
+		if contains != nil {
+			e, err := v.validate(itemPath, item, contains, depth+1)
+			if err != nil {
+				return err
+			}
+			if len(e) == 0 {
+				containsCount++
+			}
+		}
+
+		if uniqueItems {
+			for _, s := range seen {
+				eq, err := valuesEqual(s, item)
+				if err != nil {
+					return err
+				}
+				if eq {
+					errs = append(errs, SchemaError{Path: itemPath, Keyword: "uniqueItems", Message: "duplicate item"})
+					break
+				}
+			}
+			seen = append(seen, item)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if contains != nil {
+		minContains, ok, err := schemaInt(kw, "minContains")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			minContains = 1
+		}
+		if containsCount < minContains {
+			fail("contains", "must contain at least %d matching items, got %d", minContains, containsCount)
+		}
+		if maxContains, ok, err := schemaInt(kw, "maxContains"); err != nil {
+			return nil, err
+		} else if ok && containsCount > maxContains {
+			fail("maxContains", "must contain at most %d matching items, got %d", maxContains, containsCount)
+		}
+	}
+
+	return errs, nil
+}
+
+// resolveRef resolves a reference within the root schema.
+// Supported references are JSON pointers (#/$defs/name) and anchors (#name).
+func (v *schemaValidator) resolveRef(ref string) (*model.Value, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %q: only references within the schema are supported", ref)
+	}
+	fragment := ref[1:]
+
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		found, err := findSchemaAnchor(v.root, fragment)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, fmt.Errorf("could not resolve $ref %q", ref)
+		}
+		return found, nil
+	}
+
+	tokens, err := parseJSONPointer(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	cur := v.root
+	for _, token := range tokens {
+		switch {
+		case cur.IsMap():
+			cur, err = cur.GetMapKey(token)
+		case cur.IsSlice():
+			var i int
+			l, _ := cur.SliceLen()
+			i, err = parseJSONPointerIndex(token, l-1)
+			if err == nil {
+				cur, err = cur.GetSliceIndex(i)
+			}
+		default:
+			err = fmt.Errorf("cannot read %q from %s", token, cur.Type())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve $ref %q: %w", ref, err)
+		}
+	}
+	return cur, nil
+}
+
+// findSchemaAnchor returns the schema with the given $anchor, or nil if there isn't one.
+func findSchemaAnchor(schema *model.Value, anchor string) (*model.Value, error) {
+	switch {
+	case schema.IsMap():
+		if a, err := schema.GetMapKey("$anchor"); err == nil {
+			if s, err := a.StringValue(); err == nil && s == anchor {
+				return schema, nil
+			}
+		}
+		kvs, err := schema.MapKeyValues()
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			found, err := findSchemaAnchor(kv.Value, anchor)
+			if err != nil || found != nil {
+				return found, err
+			}
+		}
+	case schema.IsSlice():
+		l, err := schema.SliceLen()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < l; i++ {
+			item, err := schema.GetSliceIndex(i)
+			if err != nil {
+				return nil, err
+			}
+			found, err := findSchemaAnchor(item, anchor)
+			if err != nil || found != nil {
+				return found, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (v *schemaValidator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	v.regexps[pattern] = re
+	return re, nil
+}
+
+// schemaTypeOf returns the JSON Schema type name of the value.
+func schemaTypeOf(value *model.Value) string {
+	switch value.Type() {
+	case model.TypeMap:
+		return "object"
+	case model.TypeSlice:
+		return "array"
+	case model.TypeInt:
+		return "integer"
+	case model.TypeFloat:
+		return "number"
+	case model.TypeBool:
+		return "boolean"
+	default:
+		return value.Type().String()
+	}
+}
+
+// schemaTypeMatches reports whether the value is of the given JSON Schema type.
+// Floats with no fractional part are considered integers.
+func schemaTypeMatches(t string, value *model.Value) bool {
+	switch t {
+	case "integer":
+		if value.IsFloat() {
+			f, err := value.FloatValue()
+			return err == nil && f == math.Trunc(f)
+		}
+		return value.IsInt()
+	case "number":
+		return value.IsInt() || value.IsFloat()
+	default:
+		return schemaTypeOf(value) == t
+	}
+}
+
+// schemaNumber returns the value as a float64, if it is a number.
+func schemaNumber(value *model.Value) (float64, bool) {
+	switch {
+	case value.IsInt():
+		i, err := value.IntValue()
+		return float64(i), err == nil
+	case value.IsFloat():
+		f, err := value.FloatValue()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// schemaInt returns the non-negative integer value of the given keyword, if present.
+func schemaInt(kw map[string]*model.Value, keyword string) (int, bool, error) {
+	value, ok := kw[keyword]
+	if !ok {
+		return 0, false, nil
+	}
+	n, ok := schemaNumber(value)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return 0, false, fmt.Errorf("invalid %s: expected non-negative integer", keyword)
+	}
+	return int(n), true, nil
+}
@@ -0,0 +1,70 @@
+package execution
+
+import (
+	"github.com/tomwright/dasel/v3/model"
+)
+
+// scope contains the variables and function definitions that are visible to an expression.
+// Each execution has its own root scope, and a child scope is created for each function call
+// and for each item visited by expressions such as map and filter. Variables bound within a
+// child scope are not visible to its parent, and scopes are never shared between executions.
+type scope struct {
+	parent *scope
+	vars   map[string]*model.Value
+	defs   map[string]userFunc
+}
+
+// newScope returns a child of parent containing the given variables.
+func newScope(parent *scope, vars map[string]*model.Value) *scope {
+	return &scope{
+		parent: parent,
+		vars:   vars,
+	}
+}
+
+// lookupVar returns the variable from the innermost scope that defines it.
+func (s *scope) lookupVar(name string) (*model.Value, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// lookupDef returns the function definition from the innermost scope that defines it.
+func (s *scope) lookupDef(name string) (userFunc, bool) {
+	for ; s != nil; s = s.parent {
+		if fn, ok := s.defs[name]; ok {
+			return fn, true
+		}
+	}
+	return userFunc{}, false
+}
+
+// defNames returns the names of all function definitions visible from this scope.
+func (s *scope) defNames() []string {
+	var names []string
+	for ; s != nil; s = s.parent {
+		for name := range s.defs {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// setVar defines the variable within this scope.
+func (s *scope) setVar(name string, v *model.Value) {
+	if s.vars == nil {
+		s.vars = map[string]*model.Value{}
+	}
+	s.vars[name] = v
+}
+
+// setDef defines the function within this scope.
+func (s *scope) setDef(name string, fn userFunc) {
+	if s.defs == nil {
+		s.defs = map[string]userFunc{}
+	}
+	s.defs[name] = fn
+}
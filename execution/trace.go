@@ -0,0 +1,60 @@
+package execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// TraceEventKind is the kind of a TraceEvent.
+type TraceEventKind int
+
+const (
+	// TraceEnter is sent before an expression is evaluated.
+	TraceEnter TraceEventKind = iota
+	// TraceExit is sent after an expression has been evaluated.
+	TraceExit
+)
+
+// TraceEvent describes the evaluation of a single expression.
+type TraceEvent struct {
+	Kind TraceEventKind
+	Expr ast.Expr
+	// Path is the path of the executors the expression is being evaluated within.
+	Path string
+	// Depth is how deeply the expression is nested within the execution, starting at 0.
+	Depth int
+	Input *model.Value
+	// Output, Err and Duration are set on TraceExit events.
+	Output   *model.Value
+	Err      error
+	Duration time.Duration
+}
+
+// Tracer is called before and after each expression is evaluated.
+type Tracer func(ctx context.Context, event TraceEvent)
+
+// traceExecutor executes the expression, sending events to the tracer before and after.
+func traceExecutor(ctx context.Context, options *Options, expr ast.Expr, executor expressionExecutor, data *model.Value) (*model.Value, error) {
+	event := TraceEvent{
+		Kind:  TraceEnter,
+		Expr:  expr,
+		Path:  ExecutorPath(ctx),
+		Depth: options.usage.depth - 1,
+		Input: data,
+	}
+	options.Tracer(ctx, event)
+
+	start := time.Now()
+	res, err := executor(ctx, options, data)
+
+	event.Kind = TraceExit
+	event.Output = res
+	event.Err = err
+	event.Duration = time.Since(start)
+	options.Tracer(ctx, event)
+
+	return res, err
+}
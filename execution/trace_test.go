@@ -0,0 +1,51 @@
+package execution_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+func TestTracer(t *testing.T) {
+	var events []execution.TraceEvent
+	opts := execution.NewOptions(execution.WithTracer(func(ctx context.Context, event execution.TraceEvent) {
+		events = append(events, event)
+	}))
+	res, err := execution.ExecuteSelector(context.Background(), `1 + 2`, model.NewNullValue(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := res.IntValue(); got != 3 {
+		t.Fatalf("expected 3, got %d", got)
+	}
+
+	exp := []struct {
+		kind  execution.TraceEventKind
+		expr  string
+		depth int
+	}{
+		{kind: execution.TraceEnter, expr: `BinaryExpr "+"`, depth: 0},
+		{kind: execution.TraceEnter, expr: `NumberIntExpr 1`, depth: 1},
+		{kind: execution.TraceExit, expr: `NumberIntExpr 1`, depth: 1},
+		{kind: execution.TraceEnter, expr: `NumberIntExpr 2`, depth: 1},
+		{kind: execution.TraceExit, expr: `NumberIntExpr 2`, depth: 1},
+		{kind: execution.TraceExit, expr: `BinaryExpr "+"`, depth: 0},
+	}
+	if len(events) != len(exp) {
+		t.Fatalf("expected %d events, got %d", len(exp), len(events))
+	}
+	for i, e := range exp {
+		got := events[i]
+		if got.Kind != e.kind || ast.Describe(got.Expr) != e.expr || got.Depth != e.depth {
+			t.Errorf("event %d: expected %v %s at depth %d, got %v %s at depth %d",
+				i, e.kind, e.expr, e.depth, got.Kind, ast.Describe(got.Expr), got.Depth)
+		}
+	}
+	last := events[len(events)-1]
+	if got, _ := last.Output.IntValue(); got != 3 {
+		t.Errorf("expected final output 3, got %d", got)
+	}
+}
@@ -0,0 +1,53 @@
+// Package atomicfile writes files atomically so that readers never see a partially written file.
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile writes data to the file at the given path by writing to a temporary file
+// in the same directory and renaming it over the original.
+// The file is given the permissions perm. If the path is a symlink, the file it points to is replaced.
+func WriteFile(path string, data []byte, perm os.FileMode) (err error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	switch {
+	case err == nil:
+		path = resolved
+	case errors.Is(err, fs.ErrNotExist):
+		// The file is being created.
+	default:
+		return fmt.Errorf("error resolving file path: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("error writing temporary file: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("error syncing temporary file: %w", err)
+	}
+	if err = tmp.Chmod(perm.Perm()); err != nil {
+		return fmt.Errorf("error setting file permissions: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temporary file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing file: %w", err)
+	}
+	return nil
+}
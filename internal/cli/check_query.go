@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/selector"
+)
+
+// queryVariables are the variables given to every query by the query command.
+var queryVariables = []string{"root", "file", "fileName", "fileIndex"}
+
+type CheckQueryCmd struct {
+	Vars     []string `flag:"" name:"var" help:"The name of a variable that is passed to the query. E.g. --var foo"`
+	Libs     []string `flag:"" name:"lib" sep:"none" help:"A selector file that is imported when the query runs, given as for the query command. Functions and variables within its namespace are not checked. E.g. --lib kube=./lib/k8s.dsl"`
+	Unstable bool     `flag:"" name:"unstable" help:"Allow access to potentially unstable features."`
+
+	Files []string `arg:"" name:"files" help:"Selector files or glob patterns to check. Reads from stdin when omitted." optional:""`
+}
+
+// Run checks each of the given selector files, or stdin if no files are given, without executing them.
+// Each problem is written to stdout with its position. An exitError with code 1 is returned if any problems are found.
+// Environment variables are treated as defined, since they may be read by the query.
+func (c *CheckQueryCmd) Run(ctx *Globals) error {
+	files, err := expandFilePatterns(c.Files)
+	if err != nil {
+		return err
+	}
+
+	opts := []selector.CheckOption{
+		selector.WithVariables(queryVariables...),
+		selector.WithVariables(c.Vars...),
+		selector.WithVariables(environmentVariables()...),
+	}
+	for _, lib := range c.Libs {
+		alias, _ := parseLib(lib)
+		opts = append(opts, selector.WithImports(alias))
+	}
+	if c.Unstable {
+		opts = append(opts, selector.WithUnstable())
+	}
+
+	problems := 0
+	if len(files) == 0 {
+		var input []byte
+		if ctx.Stdin != nil {
+			input, err = io.ReadAll(ctx.Stdin)
+			if err != nil {
+				return fmt.Errorf("error reading stdin: %w", err)
+			}
+		}
+		n, err := checkQuery(ctx.Stdout, "<stdin>", string(input), opts)
+		if err != nil {
+			return err
+		}
+		problems += n
+	}
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading file %q: %w", file, err)
+		}
+		n, err := checkQuery(ctx.Stdout, file, string(contents), opts)
+		if err != nil {
+			return fmt.Errorf("error checking file %q: %w", file, err)
+		}
+		problems += n
+	}
+
+	if problems > 0 {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+// checkQuery writes each problem found in the selector to w as name:line:column: message,
+// and returns the number of problems found.
+func checkQuery(w io.Writer, name string, query string, opts []selector.CheckOption) (int, error) {
+	expr, err := selector.Parse(query)
+	if err != nil {
+		return 0, selectorError{selector: query, err: fmt.Errorf("error parsing selector: %w", err)}
+	}
+
+	problems := selector.Check(expr, execution.DefaultFuncCollection, opts...)
+	for _, problem := range problems {
+		line, column := position(query, problem.Location.Pos)
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", name, line, column, problem.Message); err != nil {
+			return 0, fmt.Errorf("error writing output: %w", err)
+		}
+	}
+	return len(problems), nil
+}
+
+// position returns the 1-based line and column of the byte offset within s.
+func position(s string, pos int) (int, int) {
+	s = s[:min(max(pos, 0), len(s))]
+	line := strings.Count(s, "\n") + 1
+	column := utf8.RuneCountInString(s[strings.LastIndexByte(s, '\n')+1:]) + 1
+	return line, column
+}
+
+// environmentVariables returns the names of the environment variables that are set.
+func environmentVariables() []string {
+	var names []string
+	for _, env := range os.Environ() {
+		if name, value, ok := strings.Cut(env, "="); ok && value != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
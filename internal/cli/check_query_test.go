@@ -0,0 +1,87 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckQuery(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"check-query"}, []byte(`users.filter(age > 18).map(name.toUpper())`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(out) != 0 {
+			t.Errorf("expected no output, got %q", out)
+		}
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		path := writeFile(t, "query.dsl", "users.filter(age > $min)\n  .map(name.toUpperCase())\n  .len(1, 2)")
+
+		out, err := runDaselCommand([]string{"check-query", path}, nil)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		exp := path + `:1:20: undefined variable: $min
+` + path + `:2:13: unknown function: "toUpperCase"
+` + path + `:3:4: func "len" expects exactly 1 arguments, got 2
+`
+		if string(out) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, out)
+		}
+	})
+
+	t.Run("variables", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"check-query", "--var", "min"}, []byte(`filter(age > $min && $root != null)`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		t.Setenv("DASEL_CHECK_QUERY_TEST", "1")
+		out, err := runDaselCommand([]string{"check-query"}, []byte(`$DASEL_CHECK_QUERY_TEST`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("libs", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"check-query", "--lib", "./lib/k8s.dsl"}, []byte(`k8s::name(metadata.name)`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("unstable", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"check-query"}, []byte(`branch(1, 2)`))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if exp := "<stdin>:1:1: branch is unstable and requires unstable features to be enabled\n"; string(out) != exp {
+			t.Errorf("expected %q, got %q", exp, out)
+		}
+
+		if _, err := runDaselCommand([]string{"check-query", "--unstable"}, []byte(`branch(1, 2)`)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := runDaselCommand([]string{"check-query"}, []byte(`foo ?`)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"reflect"
 
@@ -11,6 +12,26 @@ import (
 
 var ErrNoArgsGiven = errors.New("no arguments given")
 
+// exitError is returned by commands that need to exit with a specific exit code.
+// If err is nil, nothing is printed.
+type exitError struct {
+	code int
+	err  error
+}
+
+// Error returns the error message.
+func (e exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e exitError) Unwrap() error {
+	return e.err
+}
+
 type Globals struct {
 	Stdin       io.Reader        `kong:"-"`
 	Stdout      io.Writer        `kong:"-"`
@@ -24,7 +45,14 @@ type CLI struct {
 
 	Query       QueryCmd       `cmd:"" default:"withargs" help:"[default] Execute a query"`
 	Version     VersionCmd     `cmd:"" help:"Print the version"`
+	Explain     ExplainCmd     `cmd:"" help:"Print the syntax tree of a query"`
+	FmtQuery    FmtQueryCmd    `cmd:"" name:"fmt-query" help:"Format selector files. Comments are not preserved"`
+	CheckQuery  CheckQueryCmd  `cmd:"" name:"check-query" help:"Check selector files for problems without executing them"`
+	Funcs       FuncsCmd       `cmd:"" help:"List the functions available to queries"`
 	Interactive InteractiveCmd `cmd:"" help:"Start an interactive session (alpha)"`
+	Diff        DiffCmd        `cmd:"" help:"Show the structural differences between two documents"`
+	Patch       PatchCmd       `cmd:"" help:"Apply a JSON Patch or JSON Merge Patch to a document"`
+	Validate    ValidateCmd    `cmd:"" help:"Validate documents against a JSON Schema"`
 	Completion  CompletionCmd  `cmd:"" help:"Generate shell completion script"`
 	Man         ManCmd         `cmd:"" help:"Generate man page"`
 }
@@ -39,7 +67,22 @@ func MustRun(stdin io.Reader, stdout, stderr io.Writer) {
 		panic(err)
 	}
 
+	var exitErr exitError
+	if errors.As(err, &exitErr) {
+		if exitErr.err != nil {
+			ctx.Errorf("%s", exitErr.err.Error())
+		}
+		ctx.Exit(exitErr.code)
+		return
+	}
+
 	ctx.Errorf("%s", err.Error())
+	var selErr selectorError
+	if errors.As(err, &selErr) {
+		_, _ = fmt.Fprint(ctx.Stderr, selErr.detail())
+	} else if hint := errorHint(err); hint != "" {
+		_, _ = fmt.Fprintf(ctx.Stderr, "hint: %s\n", hint)
+	}
 	if errors.Is(err, ErrNoArgsGiven) {
 		if err := ctx.PrintUsage(false); err != nil {
 			panic(err)
@@ -8,6 +8,7 @@ import (
 	"text/template"
 
 	"github.com/alecthomas/kong"
+	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/parsing"
 )
 
@@ -36,6 +37,8 @@ type completionData struct {
 	FormatList  string
 	Formats     []string
 	ShellList   string
+	FuncList    string
+	Funcs       []string
 }
 
 func extractCompletionData(k *kong.Kong) completionData {
@@ -56,6 +59,12 @@ func extractCompletionData(k *kong.Kong) completionData {
 	data.Formats = formats
 	data.FormatList = strings.Join(formats, " ")
 
+	// Function names are completed for the funcs command.
+	for _, f := range execution.DefaultFuncCollection.List() {
+		data.Funcs = append(data.Funcs, f.Name())
+	}
+	data.FuncList = strings.Join(data.Funcs, " ")
+
 	// Global flags from the root node.
 	for _, flag := range app.Flags {
 		if flag.Hidden {
@@ -147,6 +156,7 @@ _{{.Name}}() {
     local commands="{{range $i, $s := .Subcommands}}{{if $i}} {{end}}{{$s.Name}}{{end}}"
     local formats="{{.FormatList}}"
     local shells="{{.ShellList}}"
+    local funcs="{{.FuncList}}"
 
     # Find if a subcommand has been specified
     local cmd=""
@@ -185,6 +195,8 @@ _{{.Name}}() {
     # If no subcommand yet, complete with subcommand names
     if [[ -z "${cmd}" ]]; then
         COMPREPLY=($(compgen -W "${commands}" -- "${cur}"))
+    elif [[ "${cmd}" == "funcs" ]]; then
+        COMPREPLY=($(compgen -W "${funcs}" -- "${cur}"))
     fi
 }
 
@@ -194,13 +206,14 @@ complete -F _{{.Name}} {{.Name}}
 const zshCompletionTmpl = `#compdef {{.Name}}
 
 _{{.Name}}() {
-    local -a commands formats
+    local -a commands formats funcs
     commands=(
 {{- range .Subcommands}}
         '{{.Name}}:{{.Help}}'
 {{- end}}
     )
     formats=({{range .Formats}}{{.}} {{end}})
+    funcs=({{.FuncList}})
 
     _arguments -C \
         '1:command:->command' \
@@ -243,6 +256,11 @@ _{{.Name}}() {
 {{- end}}
             esac
 
+            # Complete function names for the funcs command
+            if [[ "${words[1]}" == funcs ]]; then
+                _describe 'function' funcs
+            fi
+
             # Complete format names for --in/--out
             if [[ "${words[CURRENT-1]}" == --in || "${words[CURRENT-1]}" == --out || "${words[CURRENT-1]}" == -i || "${words[CURRENT-1]}" == -o ]]; then
                 _describe 'format' formats
@@ -277,6 +295,9 @@ complete -c {{.Name}} -l out -xa '{{.FormatList}}'
 
 # Shell completions for completion command
 complete -c {{.Name}} -n '__fish_seen_subcommand_from completion' -xa '{{.ShellList}}'
+
+# Function completions for funcs command
+complete -c {{.Name}} -n '__fish_seen_subcommand_from funcs' -xa '{{.FuncList}}'
 `
 
 const powershellCompletionTmpl = `Register-ArgumentCompleter -Native -CommandName {{.Name}} -ScriptBlock {
@@ -285,6 +306,7 @@ const powershellCompletionTmpl = `Register-ArgumentCompleter -Native -CommandNam
     $commands = @({{range .Subcommands}}'{{.Name}}', {{end}}'help')
     $formats = @({{range .Formats}}'{{.}}', {{end}}'')
     $shells = @('bash', 'zsh', 'fish', 'powershell')
+    $funcs = @({{range .Funcs}}'{{.}}', {{end}}'')
 
     # Find the subcommand
     $subcommand = $null
@@ -324,6 +346,14 @@ const powershellCompletionTmpl = `Register-ArgumentCompleter -Native -CommandNam
         return
     }
 
+    # Complete function names for funcs command
+    if ($subcommand -eq 'funcs' -and $wordToComplete -notlike '-*') {
+        $funcs | Where-Object { $_ -ne '' -and $_ -like "$wordToComplete*" } | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+        }
+        return
+    }
+
     if ($wordToComplete -like '-*') {
         $flags = @()
         switch ($subcommand) {
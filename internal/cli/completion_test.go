@@ -36,6 +36,7 @@ func TestCompletion(t *testing.T) {
 				"complete -F _dasel dasel",
 				"COMPREPLY",
 				"compgen",
+				`local funcs="abs add `,
 			},
 		},
 		{
@@ -45,6 +46,7 @@ func TestCompletion(t *testing.T) {
 				"_dasel()",
 				"_arguments",
 				"_describe",
+				"funcs=(abs add ",
 			},
 		},
 		{
@@ -53,6 +55,7 @@ func TestCompletion(t *testing.T) {
 				"complete -c dasel",
 				"__fish_use_subcommand",
 				"__fish_seen_subcommand_from",
+				"__fish_seen_subcommand_from funcs' -xa 'abs add ",
 			},
 		},
 		{
@@ -61,6 +64,7 @@ func TestCompletion(t *testing.T) {
 				"Register-ArgumentCompleter",
 				"-CommandName dasel",
 				"CompletionResult",
+				"$funcs = @('abs', 'add', ",
 			},
 		},
 	}
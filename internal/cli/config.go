@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"os/user"
+	"path/filepath"
 	"strings"
 
 	"go.yaml.in/yaml/v4"
@@ -14,6 +15,9 @@ import (
 // Config holds the contents of a config file.
 type Config struct {
 	DefaultFormat string `yaml:"default_format"`
+	// LibPaths are the directories searched for imported selector files.
+	// Relative paths are resolved against the directory of the config file.
+	LibPaths []string `yaml:"lib_paths"`
 }
 
 var cfg = Config{
@@ -28,12 +32,9 @@ func LoadConfig(path string) (Config, error) {
 		return cfg, nil
 	}
 
-	if strings.HasPrefix(path, "~/") {
-		usr, err := user.Current()
-		if err != nil {
-			return cfg, fmt.Errorf("error getting current user: %v", err)
-		}
-		path = usr.HomeDir + path[1:]
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return cfg, err
 	}
 
 	f, err := os.Open(path)
@@ -52,6 +53,28 @@ func LoadConfig(path string) (Config, error) {
 	if err != nil && !errors.Is(err, io.EOF) {
 		return cfg, fmt.Errorf("error parsing config file: %w", err)
 	}
+	for i, libPath := range cfg.LibPaths {
+		libPath, err = expandHomeDir(libPath)
+		if err != nil {
+			return cfg, err
+		}
+		if !filepath.IsAbs(libPath) {
+			libPath = filepath.Join(filepath.Dir(path), libPath)
+		}
+		cfg.LibPaths[i] = libPath
+	}
 	cfgLoaded = true
 	return cfg, nil
 }
+
+// expandHomeDir replaces a leading ~/ with the current users home directory.
+func expandHomeDir(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	usr, err := user.Current()
+	if err != nil {
+		return path, fmt.Errorf("error getting current user: %v", err)
+	}
+	return usr.HomeDir + path[1:], nil
+}
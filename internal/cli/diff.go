@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tomwright/dasel/v3/internal/valuestr"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+	"github.com/tomwright/dasel/v3/parsing/json"
+	"github.com/tomwright/dasel/v3/selector"
+)
+
+type DiffCmd struct {
+	ExtReadFlags extReadWriteFlags `flag:"" name:"read-flag" help:"Reader flag to customise parsing. E.g. --read-flag xml-mode=structured"`
+	InFormat     string            `flag:"" name:"in" short:"i" help:"The format of both documents. Detected from the file extension or contents when omitted."`
+	LeftFormat   string            `flag:"" name:"left-in" help:"The format of the left document. Overrides --in."`
+	RightFormat  string            `flag:"" name:"right-in" help:"The format of the right document. Overrides --in."`
+	Output       string            `flag:"" name:"output" short:"o" enum:"text,json" default:"text" help:"The output format of the diff. One of: text, json."`
+	Compact      bool              `flag:"" name:"compact" help:"Output in compact mode (no indentation/newlines). Applies to json output."`
+
+	ConfigPath string `name:"config" short:"c" help:"Path to config file" default:"~/dasel.yaml"`
+
+	Left  string `arg:"" help:"The path to the left document. Use - to read from stdin."`
+	Right string `arg:"" help:"The path to the right document. Use - to read from stdin."`
+}
+
+const (
+	diffAdded   = "added"
+	diffRemoved = "removed"
+	diffChanged = "changed"
+)
+
+// diffChange is a single difference between two documents.
+type diffChange struct {
+	Op   string
+	Path string
+	From *model.Value
+	To   *model.Value
+}
+
+// Run compares the left and right documents.
+// An exitError with code 1 is returned when differences are found, and with code 2 if the comparison fails.
+func (c *DiffCmd) Run(ctx *Globals) error {
+	changes, err := c.run(ctx)
+	if err != nil {
+		return exitError{code: 2, err: err}
+	}
+	if len(changes) > 0 {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+func (c *DiffCmd) run(ctx *Globals) ([]diffChange, error) {
+	cfg, err := LoadConfig(c.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Left == "-" && c.Right == "-" {
+		return nil, fmt.Errorf("only one document can be read from stdin")
+	}
+
+	leftFormat := c.LeftFormat
+	if leftFormat == "" {
+		leftFormat = c.InFormat
+	}
+	left, err := c.readDocument(ctx, cfg, c.Left, leftFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	rightFormat := c.RightFormat
+	if rightFormat == "" {
+		rightFormat = c.InFormat
+	}
+	right, err := c.readDocument(ctx, cfg, c.Right, rightFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []diffChange
+	if err := diffValues("", left, right, &changes); err != nil {
+		return nil, fmt.Errorf("error comparing documents: %w", err)
+	}
+
+	var out []byte
+	switch c.Output {
+	case "json":
+		out, err = c.formatJSON(changes)
+	default:
+		out, err = c.formatText(changes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ctx.Stdout.Write(out); err != nil {
+		return nil, fmt.Errorf("error writing output: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (c *DiffCmd) readDocument(ctx *Globals, cfg Config, path string, format string) (*model.Value, error) {
+	var data []byte
+	var err error
+	filename := path
+	if path == "-" {
+		filename = ""
+		if ctx.Stdin != nil {
+			data, err = io.ReadAll(ctx.Stdin)
+		}
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", path, err)
+	}
+
+	format, err = resolveInFormat(format, cfg.DefaultFormat, data, filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", path, err)
+	}
+
+	readerOptions := parsing.DefaultReaderOptions()
+	applyReaderFlags(&readerOptions, c.ExtReadFlags, nil)
+	reader, err := parsing.Format(format).NewReader(readerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input reader: %w", err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return model.NewNullValue(), nil
+	}
+
+	value, err := reader.Read(data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", path, err)
+	}
+	return value, nil
+}
+
+func (c *DiffCmd) formatText(changes []diffChange) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, change := range changes {
+		switch change.Op {
+		case diffAdded:
+			_, _ = fmt.Fprintf(buf, "+ %s: %s\n", change.Path, valuestr.Compact(change.To))
+		case diffRemoved:
+			_, _ = fmt.Fprintf(buf, "- %s: %s\n", change.Path, valuestr.Compact(change.From))
+		case diffChanged:
+			_, _ = fmt.Fprintf(buf, "~ %s: %s -> %s\n", change.Path, valuestr.Compact(change.From), valuestr.Compact(change.To))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *DiffCmd) formatJSON(changes []diffChange) ([]byte, error) {
+	res := model.NewSliceValue()
+	for _, change := range changes {
+		entry := model.NewMapValue()
+		if err := entry.SetMapKey("op", model.NewStringValue(change.Op)); err != nil {
+			return nil, err
+		}
+		if err := entry.SetMapKey("path", model.NewStringValue(change.Path)); err != nil {
+			return nil, err
+		}
+		if change.From != nil {
+			if err := entry.SetMapKey("from", change.From); err != nil {
+				return nil, err
+			}
+		}
+		if change.To != nil {
+			if err := entry.SetMapKey("to", change.To); err != nil {
+				return nil, err
+			}
+		}
+		if err := res.Append(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	writerOptions := parsing.DefaultWriterOptions()
+	writerOptions.Compact = c.Compact
+	writer, err := json.JSON.NewWriter(writerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output writer: %w", err)
+	}
+	return writer.Write(res)
+}
+
+// diffValues appends the differences between a and b at the given path to changes.
+// Maps are compared key by key and slices index by index. Any other values are compared using Equal.
+func diffValues(path string, a *model.Value, b *model.Value, changes *[]diffChange) error {
+	switch {
+	case a.IsMap() && b.IsMap():
+		aKeys, err := a.MapKeys()
+		if err != nil {
+			return err
+		}
+		for _, key := range aKeys {
+			aValue, err := a.GetMapKey(key)
+			if err != nil {
+				return err
+			}
+			exists, err := b.MapKeyExists(key)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				*changes = append(*changes, diffChange{Op: diffRemoved, Path: selector.PathKey(path, key), From: aValue})
+				continue
+			}
+			bValue, err := b.GetMapKey(key)
+			if err != nil {
+				return err
+			}
+			if err := diffValues(selector.PathKey(path, key), aValue, bValue, changes); err != nil {
+				return err
+			}
+		}
+		return b.RangeMap(func(key string, bValue *model.Value) error {
+			exists, err := a.MapKeyExists(key)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				*changes = append(*changes, diffChange{Op: diffAdded, Path: selector.PathKey(path, key), To: bValue})
+			}
+			return nil
+		})
+
+	case a.IsSlice() && b.IsSlice():
+		aLen, err := a.SliceLen()
+		if err != nil {
+			return err
+		}
+		bLen, err := b.SliceLen()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < max(aLen, bLen); i++ {
+			switch {
+			case i >= bLen:
+				aValue, err := a.GetSliceIndex(i)
+				if err != nil {
+					return err
+				}
+				*changes = append(*changes, diffChange{Op: diffRemoved, Path: selector.PathIndex(path, i), From: aValue})
+			case i >= aLen:
+				bValue, err := b.GetSliceIndex(i)
+				if err != nil {
+					return err
+				}
+				*changes = append(*changes, diffChange{Op: diffAdded, Path: selector.PathIndex(path, i), To: bValue})
+			default:
+				aValue, err := a.GetSliceIndex(i)
+				if err != nil {
+					return err
+				}
+				bValue, err := b.GetSliceIndex(i)
+				if err != nil {
+					return err
+				}
+				if err := diffValues(selector.PathIndex(path, i), aValue, bValue, changes); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	equal, err := diffScalarsEqual(a, b)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		*changes = append(*changes, diffChange{Op: diffChanged, Path: selector.PathRoot(path), From: a, To: b})
+	}
+	return nil
+}
+
+func diffScalarsEqual(a *model.Value, b *model.Value) (bool, error) {
+	isNumber := func(v *model.Value) bool {
+		return v.IsInt() || v.IsFloat()
+	}
+	if a.Type() != b.Type() && !(isNumber(a) && isNumber(b)) {
+		return false, nil
+	}
+	eq, err := a.Equal(b)
+	if err != nil {
+		return false, err
+	}
+	return eq.BoolValue()
+}
@@ -0,0 +1,126 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/internal/cli"
+)
+
+func runDaselCommand(args []string, in []byte) ([]byte, error) {
+	stdOut := bytes.NewBuffer([]byte{})
+	stdErr := bytes.NewBuffer([]byte{})
+
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+	}()
+
+	os.Args = append([]string{"dasel"}, args...)
+
+	_, err := cli.Run(bytes.NewReader(in), stdOut, stdErr)
+
+	return stdOut.Bytes(), err
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+
+	left := writeFile(t, "left.json", `{
+	"name": "app",
+	"replicas": 2,
+	"ports": [80, 443],
+	"labels": {"env": "dev", "team name": "core"}
+}`)
+	right := writeFile(t, "right.yaml", `name: app
+replicas: 3
+ports:
+  - 80
+labels:
+  env: dev
+  team name: platform
+  tier: web
+`)
+	same := writeFile(t, "same.toml", `name = "app"
+replicas = 2
+ports = [80, 443]
+
+[labels]
+env = "dev"
+"team name" = "core"
+`)
+
+	t.Run("text", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"diff", left, right}, nil)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		exp := `~ replicas: 2 -> 3
+- ports[1]: 443
+~ labels["team name"]: "core" -> "platform"
++ labels.tier: "web"
+`
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		got, _ := runDaselCommand([]string{"diff", "-o", "json", "--compact", left, right}, nil)
+		exp := `[{"op":"changed","path":"replicas","from":2,"to":3},{"op":"removed","path":"ports[1]","from":443},{"op":"changed","path":"labels[\"team name\"]","from":"core","to":"platform"},{"op":"added","path":"labels.tier","to":"web"}]` + "\n"
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("no differences across formats", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"diff", left, same}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no output, got %s", string(got))
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"diff", "-", left}, []byte(`{"name": "app", "replicas": 2, "ports": [80, 443], "labels": {"env": "dev", "team name": "core"}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no output, got %s", string(got))
+		}
+	})
+
+	t.Run("root change", func(t *testing.T) {
+		a := writeFile(t, "a.json", `[1]`)
+		b := writeFile(t, "b.json", `{"a": 1}`)
+		got, _ := runDaselCommand([]string{"diff", a, b}, nil)
+		exp := "~ $root: [1] -> {\"a\":1}\n"
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := runDaselCommand([]string{"diff", left, filepath.Join(dir, "missing.json")}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected not exist error, got %v", err)
+		}
+	})
+}
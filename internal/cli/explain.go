@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+type ExplainCmd struct {
+	Query string `arg:"" help:"The query to explain."`
+}
+
+// Run parses the query and prints the resulting syntax tree.
+func (c *ExplainCmd) Run(ctx *Globals) error {
+	expr, err := selector.Parse(c.Query)
+	if err != nil {
+		return selectorError{selector: c.Query, err: fmt.Errorf("error parsing selector: %w", err)}
+	}
+	return ast.Fprint(ctx.Stdout, expr)
+}
@@ -0,0 +1,26 @@
+package cli_test
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	t.Run("prints the syntax tree", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"explain", `name == "Tom"`}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `BinaryExpr "=="
+  Left: PropertyExpr
+    Property: StringExpr "name"
+  Right: StringExpr "Tom"
+`
+		if string(out) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, out)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := runDaselCommand([]string{"explain", `true ?`}, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tomwright/dasel/v3/internal/atomicfile"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+// readValueFile reads and parses the file at the given path.
+// If format is empty, it is detected from the file extension or contents, falling back to json.
+func readValueFile(path string, format string, readerOptions parsing.ReaderOptions) (*model.Value, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err = resolveInFormat(format, "json", data, path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := parsing.Format(format).NewReader(readerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reader: %w", err)
+	}
+
+	return reader.Read(data)
+}
+
+// writeFileAtomic atomically replaces the file at the given path with data.
+// The permissions of the existing file are preserved.
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error reading file info: %w", err)
+	}
+	return atomicfile.WriteFile(path, data, info.Mode())
+}
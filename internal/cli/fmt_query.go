@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+type FmtQueryCmd struct {
+	Write bool `flag:"" name:"write" short:"w" help:"Write the formatted selector back to each input file."`
+	Check bool `flag:"" name:"check" help:"Print the names of files that are not formatted instead of formatting them. Exits with code 1 if there are any."`
+
+	Files []string `arg:"" name:"files" help:"Selector files or glob patterns to format. Reads from stdin when omitted." optional:""`
+}
+
+// formattedQuery is the result of formatting a single input.
+type formattedQuery struct {
+	path    string
+	out     []byte
+	changed bool
+}
+
+// Run formats each of the given selector files, or stdin if no files are given.
+// Every input is formatted before anything is written, so a parse error leaves all files untouched.
+// Comments are not preserved.
+func (c *FmtQueryCmd) Run(ctx *Globals) error {
+	files, err := expandFilePatterns(c.Files)
+	if err != nil {
+		return err
+	}
+	if c.Write && len(files) == 0 {
+		return errors.New("--write requires at least one file")
+	}
+	if c.Write && c.Check {
+		return errors.New("--write and --check cannot be used together")
+	}
+
+	var results []formattedQuery
+	if len(files) == 0 {
+		var input []byte
+		if ctx.Stdin != nil {
+			input, err = io.ReadAll(ctx.Stdin)
+			if err != nil {
+				return fmt.Errorf("error reading stdin: %w", err)
+			}
+		}
+		res, err := formatQuery(input)
+		if err != nil {
+			return err
+		}
+		results = append(results, res)
+	}
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading file %q: %w", file, err)
+		}
+		res, err := formatQuery(contents)
+		if err != nil {
+			return fmt.Errorf("error formatting file %q: %w", file, err)
+		}
+		res.path = file
+		results = append(results, res)
+	}
+
+	unformatted := false
+	for _, res := range results {
+		switch {
+		case c.Check:
+			if res.changed {
+				unformatted = true
+				name := res.path
+				if name == "" {
+					name = "<stdin>"
+				}
+				if _, err := fmt.Fprintln(ctx.Stdout, name); err != nil {
+					return fmt.Errorf("error writing output: %w", err)
+				}
+			}
+		case c.Write:
+			if !res.changed {
+				continue
+			}
+			if err := writeFileAtomic(res.path, res.out); err != nil {
+				return fmt.Errorf("error writing file %q: %w", res.path, err)
+			}
+		default:
+			if _, err := ctx.Stdout.Write(res.out); err != nil {
+				return fmt.Errorf("error writing output: %w", err)
+			}
+		}
+	}
+	if unformatted {
+		return exitError{code: 1}
+	}
+
+	return nil
+}
+
+// formatQuery parses the selector and returns it in canonical form, followed by a newline.
+func formatQuery(input []byte) (formattedQuery, error) {
+	expr, err := selector.Parse(string(input))
+	if err != nil {
+		return formattedQuery{}, selectorError{selector: string(input), err: fmt.Errorf("error parsing selector: %w", err)}
+	}
+	out := []byte(ast.Format(expr) + "\n")
+	return formattedQuery{out: out, changed: !bytes.Equal(out, input)}, nil
+}
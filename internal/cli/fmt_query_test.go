@@ -0,0 +1,111 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFmtQuery(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		return string(contents)
+	}
+
+	t.Run("stdin", func(t *testing.T) {
+		out, err := runDaselCommand([]string{"fmt-query"}, []byte(`users.filter( age>18 && name!='Tom' ).map(name)`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exp := "users.filter(age > 18 && name != \"Tom\").map(name)\n"; string(out) != exp {
+			t.Errorf("expected %q, got %q", exp, out)
+		}
+	})
+
+	t.Run("files", func(t *testing.T) {
+		a := writeFile(t, "a.dsl", `$x=1;$x+1`)
+		b := writeFile(t, "b.dsl", `{a:1,'b':[1,2]}`)
+
+		out, err := runDaselCommand([]string{"fmt-query", a, b}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exp := "$x = 1; $x + 1\n{a: 1, b: [1, 2]}\n"; string(out) != exp {
+			t.Errorf("expected %q, got %q", exp, out)
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		path := writeFile(t, "lib.dsl", "def double(x)=$x*2")
+
+		out, err := runDaselCommand([]string{"fmt-query", "--write", path}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(out) != 0 {
+			t.Errorf("expected no output, got %q", out)
+		}
+		if exp, got := "def double(x) = $x * 2\n", readFile(t, path); got != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+	})
+
+	t.Run("write requires files", func(t *testing.T) {
+		if _, err := runDaselCommand([]string{"fmt-query", "--write"}, []byte("foo")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("check", func(t *testing.T) {
+		formatted := writeFile(t, "formatted.dsl", "foo.bar\n")
+		unformatted := writeFile(t, "unformatted.dsl", "foo . bar")
+
+		out, err := runDaselCommand([]string{"fmt-query", "--check", formatted, unformatted}, nil)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if exp := unformatted + "\n"; string(out) != exp {
+			t.Errorf("expected %q, got %q", exp, out)
+		}
+		if got := readFile(t, unformatted); got != "foo . bar" {
+			t.Errorf("expected file to be unchanged, got %q", got)
+		}
+	})
+
+	t.Run("check formatted", func(t *testing.T) {
+		formatted := writeFile(t, "formatted.dsl", "foo.bar\n")
+
+		out, err := runDaselCommand([]string{"fmt-query", "--check", formatted}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(out) != 0 {
+			t.Errorf("expected no output, got %q", out)
+		}
+	})
+
+	t.Run("parse error leaves files untouched", func(t *testing.T) {
+		good := writeFile(t, "good.dsl", "foo . bar")
+		bad := writeFile(t, "bad.dsl", "foo ?")
+
+		if _, err := runDaselCommand([]string{"fmt-query", "--write", good, bad}, nil); err == nil {
+			t.Fatal("expected error")
+		}
+		if got := readFile(t, good); got != "foo . bar" {
+			t.Errorf("expected file to be unchanged, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/tomwright/dasel/v3/execution"
+)
+
+type FuncsCmd struct {
+	Name string `arg:"" name:"name" help:"The function to describe. Lists every function when omitted." optional:""`
+}
+
+// Run lists the functions available to queries, or describes a single function in full.
+func (c *FuncsCmd) Run(ctx *Globals) error {
+	if c.Name == "" {
+		return listFuncs(ctx.Stdout, execution.DefaultFuncCollection.List())
+	}
+
+	if f, ok := execution.DefaultFuncCollection.Func(c.Name); ok {
+		return describeFunc(ctx.Stdout, f)
+	}
+	return execution.ErrUnknownFunction{Name: c.Name, Suggestion: execution.DefaultFuncCollection.Suggest(c.Name)}
+}
+
+// listFuncs writes the signature and description of each function to w, one per line.
+func listFuncs(w io.Writer, funcs []*execution.Func) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range funcs {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", f.Signature(), f.Doc().Description); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return nil
+}
+
+// describeFunc writes the signature, description and examples of the function to w.
+func describeFunc(w io.Writer, f *execution.Func) error {
+	doc := f.Doc()
+	out := f.Signature() + "\n\n" + doc.Description + "\n"
+	if len(doc.Examples) > 0 {
+		out += "\nExamples:\n"
+		for _, example := range doc.Examples {
+			out += "  " + example.Selector + "\n  => " + example.Output + "\n"
+		}
+	}
+	if _, err := io.WriteString(w, out); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return nil
+}
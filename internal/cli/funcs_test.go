@@ -0,0 +1,70 @@
+package cli_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+)
+
+func TestFuncs(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		stdout, _, err := runDaselCmd([]string{"funcs"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+		if !strings.HasPrefix(lines[0], "abs([value number]) number  ") {
+			t.Errorf("expected abs to be listed first, got %q", lines[0])
+		}
+		found := false
+		for _, line := range lines {
+			if strings.HasPrefix(line, "toUpper([value string]) string ") && strings.HasSuffix(line, "Converts a string to upper case.") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected toUpper to be listed, got:\n%s", stdout)
+		}
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		stdout, _, err := runDaselCmd([]string{"funcs", "trimPrefix"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `trimPrefix([value string], prefix string) string
+
+Removes a prefix from a string, if present.
+
+Examples:
+  trimPrefix("v1.2", "v")
+  => "1.2"
+`
+		if stdout != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, stdout)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, _, err := runDaselCmd([]string{"funcs", "nope"})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if exp := `unknown function: "nope"`; err.Error() != exp {
+			t.Errorf("expected error %q, got %q", exp, err.Error())
+		}
+	})
+
+	t.Run("unknown with suggestion", func(t *testing.T) {
+		_, _, err := runDaselCmd([]string{"funcs", "toUpperr"})
+		var unknown execution.ErrUnknownFunction
+		if !errors.As(err, &unknown) {
+			t.Fatalf("expected ErrUnknownFunction, got %v", err)
+		}
+		if unknown.Suggestion != "toUpper" {
+			t.Errorf("expected suggestion %q, got %q", "toUpper", unknown.Suggestion)
+		}
+	})
+}
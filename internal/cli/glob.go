@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// expandFilePatterns expands the given file paths and glob patterns into a list of file paths.
+// Patterns support the standard filepath.Match syntax along with ** to match any number of directories.
+// Paths that are not patterns are returned as-is. Duplicate paths are removed.
+func expandFilePatterns(patterns []string) ([]string, error) {
+	var res []string
+	seen := map[string]struct{}{}
+	add := func(p string) {
+		if _, ok := seen[p]; ok {
+			return
+		}
+		seen[p] = struct{}{}
+		res = append(res, p)
+	}
+
+	for _, pattern := range patterns {
+		if !isGlobPattern(pattern) {
+			add(pattern)
+			continue
+		}
+		matches, err := globFiles(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files matched pattern %q", pattern)
+		}
+		for _, m := range matches {
+			add(m)
+		}
+	}
+	return res, nil
+}
+
+func isGlobPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// globFiles returns the regular files matching the given pattern in lexical order.
+func globFiles(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(pattern)
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(filepath.FromSlash(pattern))
+		if err != nil {
+			return nil, err
+		}
+		res := make([]string, 0, len(matches))
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+				res = append(res, m)
+			}
+		}
+		return res, nil
+	}
+
+	// Walk from the longest prefix that does not contain a pattern.
+	pattern = path.Clean(pattern)
+	root := "."
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if isGlobPattern(segment) {
+			if i > 0 {
+				root = strings.Join(segments[:i], "/")
+				if root == "" {
+					root = "/"
+				}
+			}
+			break
+		}
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	var res []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if matchSegments(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(p), "/")) {
+			res = append(res, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// matchSegments reports whether the path segments match the pattern segments.
+// A ** pattern segment matches zero or more path segments.
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
@@ -11,6 +11,7 @@ import (
 func NewInteractiveCmd(queryCmd *QueryCmd) *InteractiveCmd {
 	return &InteractiveCmd{
 		Vars:              queryCmd.Vars,
+		Libs:              queryCmd.Libs,
 		ExtReadWriteFlags: queryCmd.ExtReadWriteFlags,
 		ExtReadFlags:      queryCmd.ExtReadFlags,
 		ExtWriteFlags:     queryCmd.ExtWriteFlags,
@@ -23,10 +24,11 @@ func NewInteractiveCmd(queryCmd *QueryCmd) *InteractiveCmd {
 
 type InteractiveCmd struct {
 	Vars              variables         `flag:"" name:"var" help:"Variables to pass to the query. E.g. --var foo=\"bar\" --var baz=json:file:./some/file.json"`
+	Libs              []string          `flag:"" name:"lib" sep:"none" help:"Import a selector file, exposing its definitions under a namespace. The namespace defaults to the file name. E.g. --lib ./lib/k8s.dsl --lib kube=./lib/k8s.dsl"`
 	ExtReadWriteFlags extReadWriteFlags `flag:"" name:"rw-flag" help:"Read/Write flag to customise parsing/output. Applies to read + write E.g. --rw-flag csv-delimiter=;"`
 	ExtReadFlags      extReadWriteFlags `flag:"" name:"read-flag" help:"Reader flag to customise parsing. E.g. --read-flag xml-mode=structured"`
 	ExtWriteFlags     extReadWriteFlags `flag:"" name:"write-flag" help:"Writer flag to customise output. E.g. --write-flag csv-delimiter=;"`
-	InFormat          string            `flag:"" name:"in" short:"i" help:"The format of the input data."`
+	InFormat          string            `flag:"" name:"in" short:"i" help:"The format of the input data. Detected from the contents when omitted or set to auto."`
 	OutFormat         string            `flag:"" name:"out" short:"o" help:"The format of the output data."`
 
 	ConfigPath string `name:"config" short:"c" help:"Path to config file" default:"~/dasel.yaml"`
@@ -51,11 +53,13 @@ func (c *InteractiveCmd) Run(ctx *Globals) error {
 	}
 
 	if c.InFormat == "" && c.OutFormat == "" {
-		c.InFormat = cfg.DefaultFormat
 		c.OutFormat = cfg.DefaultFormat
-	} else if c.InFormat == "" {
-		c.InFormat = c.OutFormat
-	} else if c.OutFormat == "" {
+	}
+	c.InFormat, err = resolveInFormat(c.InFormat, c.OutFormat, stdInBytes, "")
+	if err != nil {
+		return err
+	}
+	if c.OutFormat == "" {
 		c.OutFormat = c.InFormat
 	}
 
@@ -74,6 +78,7 @@ func (c *InteractiveCmd) Run(ctx *Globals) error {
 
 		o := runOpts{
 			Vars:              c.Vars,
+			Libs:              c.Libs,
 			ExtReadWriteFlags: c.ExtReadWriteFlags,
 			ExtReadFlags:      c.ExtReadFlags,
 			ExtWriteFlags:     c.ExtWriteFlags,
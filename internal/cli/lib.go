@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/execution"
+)
+
+// libOptions returns the execution options needed to import the given libs.
+// Each lib is given as [alias=]path. If no alias is given, the file name
+// without its extension is used.
+// E.g. --lib ./lib/k8s.dsl
+// E.g. --lib kube=./lib/k8s.dsl
+func libOptions(libs []string) []execution.ExecuteOptionFn {
+	var opts []execution.ExecuteOptionFn
+	for _, lib := range libs {
+		alias, path := parseLib(lib)
+		opts = append(opts, execution.WithImport(path, alias))
+	}
+	return opts
+}
+
+// parseLib splits a lib given as [alias=]path into its alias and path.
+func parseLib(lib string) (string, string) {
+	alias, path, ok := strings.Cut(lib, "=")
+	if !ok {
+		path = lib
+		alias = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return alias, path
+}
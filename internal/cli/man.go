@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/internal"
 )
 
@@ -35,6 +36,12 @@ type manData struct {
 	Subcommands []manSubcommand
 	GlobalFlags []manFlag
 	QueryFlags  []manFlag
+	Funcs       []manFunc
+}
+
+type manFunc struct {
+	Signature   string
+	Description string
 }
 
 const dateFormat = "2006-01-02"
@@ -108,6 +115,13 @@ func extractManData(k *kong.Kong) manData {
 		data.Subcommands = append(data.Subcommands, sub)
 	}
 
+	for _, f := range execution.DefaultFuncCollection.List() {
+		data.Funcs = append(data.Funcs, manFunc{
+			Signature:   f.Signature(),
+			Description: f.Doc().Description,
+		})
+	}
+
 	return data
 }
 
@@ -173,7 +187,13 @@ The following options are available for the default \fBquery\fR command:
 {{if .Short}}\fB\-{{.Short}}\fR, {{end}}\fB\-\-{{.Name}}\fR
 {{.Help}}
 {{end}}{{end}}{{end}}
-.SH EXAMPLES
+.SH FUNCTIONS
+The following functions are available to queries.
+Use \fB{{.Name | toLower}} funcs\fR \fIname\fR for examples.
+{{range .Funcs}}.TP
+.B {{.Signature}}
+{{.Description}}
+{{end}}.SH EXAMPLES
 .TP
 Query JSON from stdin:
 echo '{"name": "Tom"}' | {{.Name | toLower}} 'name'
@@ -183,6 +203,36 @@ echo '{"name": "Tom"}' | {{.Name | toLower}} -i json -o yaml
 .TP
 Query with compact output:
 echo '{"name": "Tom"}' | {{.Name | toLower}} -i json -o json --compact
+.TP
+Modify a file in place:
+{{.Name | toLower}} -f config.yaml --write 'version = "2.0.0"'
+.TP
+Query many files, prefixing results with the filename:
+{{.Name | toLower}} --files-mode=prefix 'metadata.name' 'k8s/**/*.yaml'
+.TP
+Compare two documents, possibly in different formats:
+{{.Name | toLower}} diff staging.yaml production.json
+.TP
+Apply a JSON Patch to a file in place:
+{{.Name | toLower}} patch --patch-file patch.json --write config.yaml
+.TP
+Validate files against a JSON Schema:
+{{.Name | toLower}} validate --schema schema.json 'k8s/**/*.yaml'
+.TP
+Use functions defined in a selector file:
+{{.Name | toLower}} -f deployment.yaml --lib ./lib/k8s.dsl 'k8s::name(metadata.name)'
+.TP
+Show how a query is parsed:
+{{.Name | toLower}} explain 'users.filter(age > 18)'
+.TP
+Format selector files in place:
+{{.Name | toLower}} fmt-query --write 'lib/*.dsl'
+.TP
+Check selector files for unknown functions and undefined variables:
+{{.Name | toLower}} check-query --var env 'queries/*.dsl'
+.TP
+Describe a function and show examples of its use:
+{{.Name | toLower}} funcs replaceRegex
 .SH SEE ALSO
 .UR https://daseldocs.tomwright.me
 Dasel documentation
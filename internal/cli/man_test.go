@@ -34,6 +34,13 @@ func TestMan(t *testing.T) {
 		}
 	}
 
+	// Functions
+	for _, f := range []string{".SH FUNCTIONS", "join(separator string, values ...any) string"} {
+		if !strings.Contains(stdout, f) {
+			t.Errorf("expected output to contain function %q", f)
+		}
+	}
+
 	// Key flags (roff uses \- for dashes)
 	for _, flag := range []string{"in", "out", "compact"} {
 		if !strings.Contains(stdout, flag) {
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+type PatchCmd struct {
+	ExtReadWriteFlags extReadWriteFlags `flag:"" name:"rw-flag" help:"Read/Write flag to customise parsing/output. Applies to read + write E.g. --rw-flag csv-delimiter=;"`
+	ExtReadFlags      extReadWriteFlags `flag:"" name:"read-flag" help:"Reader flag to customise parsing. E.g. --read-flag xml-mode=structured"`
+	ExtWriteFlags     extReadWriteFlags `flag:"" name:"write-flag" help:"Writer flag to customise output. E.g. --write-flag csv-delimiter=;"`
+	PatchFile         string            `flag:"" name:"patch-file" short:"p" required:"" help:"The file containing the patch to apply."`
+	PatchFormat       string            `flag:"" name:"patch-in" help:"The format of the patch file. Detected from the file extension or contents when omitted."`
+	Type              string            `flag:"" name:"type" short:"t" enum:"auto,json,merge" default:"auto" help:"The type of patch. One of: auto (json patch if the patch is an array, otherwise merge patch), json (RFC 6902), merge (RFC 7386)."`
+	InFormat          string            `flag:"" name:"in" short:"i" help:"The format of the input data. Detected from the file extension or contents when omitted or set to auto."`
+	OutFormat         string            `flag:"" name:"out" short:"o" help:"The format of the output data."`
+	Compact           bool              `flag:"" name:"compact" help:"Output in compact mode (no indentation/newlines)."`
+	Write             bool              `flag:"" name:"write" short:"w" aliases:"in-place" help:"Write the patched document back to each input file."`
+
+	ConfigPath string `name:"config" short:"c" help:"Path to config file" default:"~/dasel.yaml"`
+
+	Files []string `arg:"" name:"files" help:"Files or glob patterns to patch. Reads from stdin when omitted." optional:""`
+}
+
+const (
+	patchTypeJSON  = "json"
+	patchTypeMerge = "merge"
+)
+
+// Run applies the patch to each of the given files, or stdin if no files are given.
+// Every input is patched before anything is written, so a failure leaves all files untouched.
+func (c *PatchCmd) Run(ctx *Globals) error {
+	cfg, err := LoadConfig(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := expandFilePatterns(c.Files)
+	if err != nil {
+		return err
+	}
+	if c.Write && len(files) == 0 {
+		return errors.New("--write requires at least one file")
+	}
+
+	patch, err := c.readPatch()
+	if err != nil {
+		return err
+	}
+
+	var results []fileOutput
+	if len(files) == 0 {
+		var input []byte
+		if ctx.Stdin != nil {
+			input, err = io.ReadAll(ctx.Stdin)
+			if err != nil {
+				return fmt.Errorf("error reading stdin: %w", err)
+			}
+		}
+		out, err := c.patch(cfg, patch, input, "")
+		if err != nil {
+			return err
+		}
+		results = append(results, fileOutput{out: out})
+	}
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading file %q: %w", file, err)
+		}
+		out, err := c.patch(cfg, patch, contents, file)
+		if err != nil {
+			return fmt.Errorf("error patching file %q: %w", file, err)
+		}
+		results = append(results, fileOutput{path: file, out: out})
+	}
+
+	for _, res := range results {
+		if c.Write {
+			if err := writeFileAtomic(res.path, res.out); err != nil {
+				return fmt.Errorf("error writing file %q: %w", res.path, err)
+			}
+			continue
+		}
+		if _, err := ctx.Stdout.Write(res.out); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readPatch reads and parses the patch file.
+func (c *PatchCmd) readPatch() (*model.Value, error) {
+	readerOptions := parsing.DefaultReaderOptions()
+	applyReaderFlags(&readerOptions, c.ExtReadFlags, c.ExtReadWriteFlags)
+	patch, err := readValueFile(c.PatchFile, c.PatchFormat, readerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error reading patch file: %w", err)
+	}
+	return patch, nil
+}
+
+// patch applies the patch to the given input and returns the output bytes.
+func (c *PatchCmd) patch(cfg Config, patch *model.Value, input []byte, filename string) ([]byte, error) {
+	fallback := c.OutFormat
+	if fallback == "" {
+		fallback = cfg.DefaultFormat
+	}
+	inFormat, err := resolveInFormat(c.InFormat, fallback, input, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := resolveFormats(runOpts{
+		ExtReadWriteFlags: c.ExtReadWriteFlags,
+		ExtReadFlags:      c.ExtReadFlags,
+		ExtWriteFlags:     c.ExtWriteFlags,
+		InFormat:          inFormat,
+		OutFormat:         c.OutFormat,
+		Compact:           c.Compact,
+
+		ConfigPath: c.ConfigPath,
+
+		Stdin: bytes.NewReader(input),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := execute(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched *model.Value
+	switch c.Type {
+	case patchTypeJSON:
+		patched, err = execution.ApplyJSONPatch(doc, patch)
+	case patchTypeMerge:
+		patched, err = execution.ApplyMergePatch(doc, patch)
+	default:
+		if patch.IsSlice() {
+			patched, err = execution.ApplyJSONPatch(doc, patch)
+		} else {
+			patched, err = execution.ApplyMergePatch(doc, patch)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return write(o, patched)
+}
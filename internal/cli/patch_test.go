@@ -0,0 +1,90 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		return string(contents)
+	}
+
+	jsonPatch := writeFile(t, "patch.json", `[
+	{"op": "test", "path": "/name", "value": "app"},
+	{"op": "replace", "path": "/replicas", "value": 3},
+	{"op": "add", "path": "/ports/-", "value": 8080}
+]`)
+	mergePatch := writeFile(t, "merge.yaml", `replicas: 5
+ports: null
+`)
+	failingPatch := writeFile(t, "failing.json", `[
+	{"op": "replace", "path": "/replicas", "value": 3},
+	{"op": "test", "path": "/name", "value": "other"}
+]`)
+
+	t.Run("json patch yaml stdin", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"patch", "--patch-file", jsonPatch, "-i", "yaml"}, []byte("name: app\nreplicas: 1\nports:\n  - 80\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := "name: app\nreplicas: 3\nports:\n    - 80\n    - 8080\n"
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("merge patch toml file", func(t *testing.T) {
+		file := writeFile(t, "config.toml", "name = \"app\"\nreplicas = 1\nports = [80]\n")
+		got, err := runDaselCommand([]string{"patch", "--patch-file", mergePatch, file}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := "name = 'app'\nreplicas = 5\n"
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		file := writeFile(t, "write.json", `{"name": "app", "replicas": 1, "ports": [80]}`)
+		_, err := runDaselCommand([]string{"patch", "--patch-file", jsonPatch, "--write", "--compact", file}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exp := `{"name":"app","replicas":3,"ports":[80,8080]}` + "\n"
+		if got := readFile(t, file); got != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+		}
+	})
+
+	t.Run("failed patch leaves files untouched", func(t *testing.T) {
+		a := writeFile(t, "a.json", `{"name": "other", "replicas": 1}`)
+		b := writeFile(t, "b.json", `{"name": "app", "replicas": 1}`)
+		_, err := runDaselCommand([]string{"patch", "--patch-file", failingPatch, "--write", b, a}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if got := readFile(t, a); got != `{"name": "other", "replicas": 1}` {
+			t.Errorf("file a was modified: %s", got)
+		}
+		if got := readFile(t, b); got != `{"name": "app", "replicas": 1}` {
+			t.Errorf("file b was modified: %s", got)
+		}
+	})
+}
@@ -1,68 +1,278 @@
 package cli
 
-import "fmt"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
 
 type QueryCmd struct {
 	Vars              variables         `flag:"" name:"var" help:"Variables to pass to the query. E.g. --var foo=\"bar\" --var baz=json:file:./some/file.json"`
+	Libs              []string          `flag:"" name:"lib" sep:"none" help:"Import a selector file, exposing its definitions under a namespace. The namespace defaults to the file name. E.g. --lib ./lib/k8s.dsl --lib kube=./lib/k8s.dsl"`
 	ExtReadWriteFlags extReadWriteFlags `flag:"" name:"rw-flag" help:"Read/Write flag to customise parsing/output. Applies to read + write E.g. --rw-flag csv-delimiter=;"`
 	ExtReadFlags      extReadWriteFlags `flag:"" name:"read-flag" help:"Reader flag to customise parsing. E.g. --read-flag xml-mode=structured"`
 	ExtWriteFlags     extReadWriteFlags `flag:"" name:"write-flag" help:"Writer flag to customise output. E.g. --write-flag csv-delimiter=;"`
-	InFormat          string            `flag:"" name:"in" short:"i" help:"The format of the input data."`
+	InFormat          string            `flag:"" name:"in" short:"i" help:"The format of the input data. Detected from the file extension or contents when omitted or set to auto."`
 	OutFormat         string            `flag:"" name:"out" short:"o" help:"The format of the output data."`
 	ReturnRoot        bool              `flag:"" name:"root" help:"Return the root value."`
 	Compact           bool              `flag:"" name:"compact" help:"Output in compact mode (no indentation/newlines)."`
 	Unstable          bool              `flag:"" name:"unstable" help:"Allow access to potentially unstable features."`
+	Timeout           time.Duration     `flag:"" name:"timeout" help:"Maximum time the query may run for on each input. E.g. --timeout 5s"`
+	MaxSteps          int               `flag:"" name:"max-steps" help:"Maximum number of expressions the query may evaluate on each input."`
+	Trace             bool              `flag:"" name:"trace" help:"Write each evaluated expression with its input, output and timing to stderr."`
 	Interactive       bool              `flag:"" name:"it" help:"Run in interactive mode (alpha)."`
+	Files             []string          `flag:"" name:"file" short:"f" sep:"none" help:"Read input from the given file or glob pattern instead of stdin. May be given multiple times."`
+	Write             bool              `flag:"" name:"write" short:"w" aliases:"in-place" help:"Write the root value back to each input file."`
+	FilesMode         string            `flag:"" name:"files-mode" enum:"default,prefix,combine" default:"default" help:"How to output results when reading files. One of: default, prefix (prefix each line with the filename), combine (output a single document keyed by filename)."`
 
 	ConfigPath string `name:"config" short:"c" help:"Path to config file" default:"~/dasel.yaml"`
 
-	Query string `arg:"" help:"The query to execute." optional:"" default:""`
+	Query    string   `arg:"" help:"The query to execute." optional:"" default:""`
+	FileArgs []string `arg:"" name:"files" help:"Files or glob patterns to read input from. Equivalent to --file." optional:""`
 }
 
+const (
+	filesModeDefault = "default"
+	filesModePrefix  = "prefix"
+	filesModeCombine = "combine"
+)
+
 func (c *QueryCmd) Run(ctx *Globals) error {
 	cfg, err := LoadConfig(c.ConfigPath)
 	if err != nil {
 		return err
 	}
 
+	files, err := expandFilePatterns(append(slices.Clone(c.Files), c.FileArgs...))
+	if err != nil {
+		return err
+	}
+
+	if c.Write && len(files) == 0 {
+		return errors.New("--write requires at least one file")
+	}
+	if c.Write && c.FilesMode != filesModeDefault {
+		return fmt.Errorf("--write cannot be used with --files-mode=%s", c.FilesMode)
+	}
+
+	if c.Interactive {
+		if c.Write {
+			return errors.New("--write cannot be used in interactive mode")
+		}
+		if len(files) > 1 {
+			return errors.New("interactive mode supports a single file")
+		}
+		globals := *ctx
+		if len(files) == 1 {
+			contents, err := os.ReadFile(files[0])
+			if err != nil {
+				return fmt.Errorf("error reading file: %w", err)
+			}
+			globals.Stdin = bytes.NewReader(contents)
+			c.InFormat, err = c.inFormatForFile(cfg, contents, files[0])
+			if err != nil {
+				return err
+			}
+		}
+		return NewInteractiveCmd(c).Run(&globals)
+	}
+
+	if len(files) > 0 {
+		return c.runFiles(ctx, cfg, files)
+	}
+
+	var stdin io.Reader
+	var input []byte
+	if ctx.Stdin != nil {
+		input, err = io.ReadAll(ctx.Stdin)
+		if err != nil {
+			return fmt.Errorf("error reading stdin: %w", err)
+		}
+		stdin = bytes.NewReader(input)
+	}
+
 	if c.InFormat == "" && c.OutFormat == "" {
-		c.InFormat = cfg.DefaultFormat
 		c.OutFormat = cfg.DefaultFormat
 	}
+	inFormat, err := resolveInFormat(c.InFormat, c.OutFormat, input, "")
+	if err != nil {
+		return err
+	}
 
-	if c.Query == "" && c.InFormat == "" && ctx.Stdin == nil {
+	if c.Query == "" && inFormat == "" && stdin == nil {
 		return ErrNoArgsGiven
 	}
 
-	if c.Interactive {
-		return NewInteractiveCmd(c).Run(ctx)
+	outBytes, err := run(c.runOpts(ctx, stdin, inFormat))
+	if err != nil {
+		return err
 	}
 
-	o := runOpts{
+	_, err = ctx.Stdout.Write(outBytes)
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+func (c *QueryCmd) runOpts(ctx *Globals, stdin io.Reader, inFormat string, opts ...execution.ExecuteOptionFn) runOpts {
+	var trace io.Writer
+	if c.Trace {
+		trace = ctx.Stderr
+	}
+	return runOpts{
 		Vars:              c.Vars,
+		Libs:              c.Libs,
 		ExtReadWriteFlags: c.ExtReadWriteFlags,
 		ExtReadFlags:      c.ExtReadFlags,
 		ExtWriteFlags:     c.ExtWriteFlags,
-		InFormat:          c.InFormat,
+		InFormat:          inFormat,
 		OutFormat:         c.OutFormat,
 		Compact:           c.Compact,
-		ReturnRoot:        c.ReturnRoot,
+		ReturnRoot:        c.ReturnRoot || c.Write,
 		Unstable:          c.Unstable,
+		Timeout:           c.Timeout,
+		MaxSteps:          c.MaxSteps,
+		Trace:             trace,
 		Query:             c.Query,
+		ExecuteOpts:       opts,
 
 		ConfigPath: c.ConfigPath,
 
-		Stdin: ctx.Stdin,
+		Stdin: stdin,
 	}
-	outBytes, err := run(o)
-	if err != nil {
-		return err
+}
+
+// inFormatForFile returns the input format to use for the given file.
+// An explicit --in takes precedence over the file extension and contents.
+func (c *QueryCmd) inFormatForFile(cfg Config, contents []byte, path string) (string, error) {
+	fallback := c.OutFormat
+	if fallback == "" {
+		fallback = cfg.DefaultFormat
 	}
+	return resolveInFormat(c.InFormat, fallback, contents, path)
+}
 
-	_, err = ctx.Stdout.Write(outBytes)
-	if err != nil {
-		return fmt.Errorf("error writing output: %w", err)
+// fileOutput is the output produced for a single input file.
+type fileOutput struct {
+	path string
+	out  []byte
+}
+
+// runFiles executes the query against each of the given files.
+// Every file is processed before anything is written, so a failure leaves all files untouched.
+// Files written with --write keep their own format, and files of different formats can only be combined with --out.
+func (c *QueryCmd) runFiles(ctx *Globals, cfg Config, files []string) error {
+	var combined *model.Value
+	var combinedOpts runOpts
+	if c.FilesMode == filesModeCombine {
+		combined = model.NewMapValue()
+	}
+
+	var results []fileOutput
+	for i, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading file %q: %w", file, err)
+		}
+
+		inFormat, err := c.inFormatForFile(cfg, contents, file)
+		if err != nil {
+			return fmt.Errorf("error processing file %q: %w", file, err)
+		}
+
+		o, err := resolveFormats(c.runOpts(
+			ctx,
+			bytes.NewReader(contents),
+			inFormat,
+			execution.WithVariable("file", model.NewStringValue(file)),
+			execution.WithVariable("fileName", model.NewStringValue(filepath.Base(file))),
+			execution.WithVariable("fileIndex", model.NewIntValue(int64(i))),
+		))
+		if err != nil {
+			return err
+		}
+		if c.Write && o.OutFormat != o.InFormat {
+			return fmt.Errorf("--write cannot change the format of file %q from %s to %s", file, o.InFormat, o.OutFormat)
+		}
+		if combined != nil && i > 0 && c.OutFormat == "" && o.OutFormat != combinedOpts.OutFormat {
+			return fmt.Errorf("cannot combine files of different formats (%s and %s) without --out", combinedOpts.OutFormat, o.OutFormat)
+		}
+
+		out, err := execute(o)
+		if err != nil {
+			return fmt.Errorf("error processing file %q: %w", file, err)
+		}
+
+		if combined != nil {
+			if i == 0 {
+				combinedOpts = o
+			}
+			if err := combined.SetMapKey(file, out); err != nil {
+				return fmt.Errorf("error combining results: %w", err)
+			}
+			continue
+		}
+
+		outBytes, err := write(o, out)
+		if err != nil {
+			return fmt.Errorf("error processing file %q: %w", file, err)
+		}
+		results = append(results, fileOutput{path: file, out: outBytes})
+	}
+
+	if combined != nil {
+		outBytes, err := write(combinedOpts, combined)
+		if err != nil {
+			return err
+		}
+		results = append(results, fileOutput{out: outBytes})
+	}
+
+	for _, res := range results {
+		if c.Write {
+			if err := writeFileAtomic(res.path, res.out); err != nil {
+				return fmt.Errorf("error writing file %q: %w", res.path, err)
+			}
+			continue
+		}
+
+		outBytes := res.out
+		if c.FilesMode == filesModePrefix {
+			outBytes = prefixLines(res.path+":", outBytes)
+		}
+		if _, err := ctx.Stdout.Write(outBytes); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// prefixLines prefixes each line in the given output with the given prefix.
+func prefixLines(prefix string, output []byte) []byte {
+	if len(output) == 0 {
+		return output
+	}
+	trailingNewline := bytes.HasSuffix(output, []byte("\n"))
+	lines := bytes.Split(bytes.TrimSuffix(output, []byte("\n")), []byte("\n"))
+	buf := new(bytes.Buffer)
+	for _, line := range lines {
+		buf.WriteString(prefix)
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if !trailingNewline {
+		buf.Truncate(buf.Len() - 1)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,317 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/parsing"
+	_ "github.com/tomwright/dasel/v3/parsing/hcl"
+	_ "github.com/tomwright/dasel/v3/parsing/ini"
+	_ "github.com/tomwright/dasel/v3/parsing/xml"
+)
+
+func TestQueryFile(t *testing.T) {
+	writeFile := func(t *testing.T, name string, contents string, perm os.FileMode) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(contents), perm); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("read file", func(t *testing.T) {
+		path := writeFile(t, "data.json", `{"name": "Tom"}`, 0600)
+		runTest(testCase{
+			args:   []string{"-f", path, "name"},
+			stdout: []byte("\"Tom\"\n"),
+		})(t)
+	})
+
+	t.Run("read file with format override", func(t *testing.T) {
+		path := writeFile(t, "data.txt", `name: Tom`, 0600)
+		runTest(testCase{
+			args:   []string{"-f", path, "-i", "yaml", "-o", "json", "name"},
+			stdout: []byte("\"Tom\"\n"),
+		})(t)
+	})
+
+	t.Run("write in place", func(t *testing.T) {
+		path := writeFile(t, "data.yaml", "name: Tom\nage: 30\n", 0640)
+		runTest(testCase{
+			args: []string{"-f", path, "--write", `age = 31`},
+		})(t)
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		exp := "name: Tom\nage: 31\n"
+		if string(got) != exp {
+			t.Errorf("expected file contents %q, got %q", exp, string(got))
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file info: %v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("expected file mode %v, got %v", os.FileMode(0640), info.Mode().Perm())
+		}
+	})
+
+	t.Run("in-place alias", func(t *testing.T) {
+		path := writeFile(t, "data.json", `{"x": 1}`, 0600)
+		runTest(testCase{
+			args: []string{"-f", path, "--in-place", `x = 2`},
+		})(t)
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		exp := "{\n    \"x\": 2\n}\n"
+		if string(got) != exp {
+			t.Errorf("expected file contents %q, got %q", exp, string(got))
+		}
+	})
+
+	t.Run("failed query leaves file untouched", func(t *testing.T) {
+		path := writeFile(t, "data.json", `{"x": 1}`, 0600)
+		_, _, err := runDasel([]string{"-f", path, "--write", `x = $missing`}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		if string(got) != `{"x": 1}` {
+			t.Errorf("expected file to be unchanged, got %q", string(got))
+		}
+	})
+
+	t.Run("write without file", func(t *testing.T) {
+		_, _, err := runDasel([]string{"-i", "json", "--write", `x = 2`}, []byte(`{"x": 1}`))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestQueryFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("unexpected error creating directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+
+	a := writeFile(t, "a.yaml", "name: a\n")
+	b := writeFile(t, "nested/b.yaml", "name: b\n")
+	c := writeFile(t, "nested/deep/c.json", `{"name": "c"}`)
+
+	t.Run("multiple file args", runTest(testCase{
+		args:   []string{"-o", "json", "name", a, c},
+		stdout: []byte("\"a\"\n\"c\"\n"),
+	}))
+
+	t.Run("file variables", runTest(testCase{
+		args:   []string{"-f", a, "-f", c, "-o", "json", "--compact", `{"file": $fileName, "index": $fileIndex, "name": name}`},
+		stdout: []byte("{\"file\":\"a.yaml\",\"index\":0,\"name\":\"a\"}\n{\"file\":\"c.json\",\"index\":1,\"name\":\"c\"}\n"),
+	}))
+
+	t.Run("recursive glob", runTest(testCase{
+		args:   []string{"-o", "json", "-f", filepath.Join(dir, "**", "*.yaml"), "name"},
+		stdout: []byte("\"a\"\n\"b\"\n"),
+	}))
+
+	t.Run("glob without matches", func(t *testing.T) {
+		_, _, err := runDasel([]string{"-f", filepath.Join(dir, "*.toml"), "name"}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("prefix mode", runTest(testCase{
+		args:   []string{"--files-mode", "prefix", "-o", "json", "name", a, b},
+		stdout: []byte(a + ":\"a\"\n" + b + ":\"b\"\n"),
+	}))
+
+	t.Run("combine mode", runTest(testCase{
+		args:   []string{"--files-mode", "combine", "-o", "json", "--compact", "name", a, b},
+		stdout: []byte(`{"` + a + `":"a","` + b + `":"b"}` + "\n"),
+	}))
+
+	t.Run("combine mode with different formats", func(t *testing.T) {
+		_, _, err := runDasel([]string{"--files-mode", "combine", "name", a, c}, nil)
+		if err == nil || !strings.Contains(err.Error(), "without --out") {
+			t.Fatalf("expected error about --out, got %v", err)
+		}
+	})
+
+	t.Run("write cannot change format", func(t *testing.T) {
+		x := writeFile(t, "convert/x.yaml", "name: x\n")
+		if _, _, err := runDasel([]string{"--write", "-o", "json", `name = "y"`, x}, nil); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		got, err := os.ReadFile(x)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		if exp := "name: x\n"; string(got) != exp {
+			t.Errorf("expected file contents %q, got %q", exp, string(got))
+		}
+	})
+
+	t.Run("write each file", func(t *testing.T) {
+		x := writeFile(t, "write/x.yaml", "name: x\n")
+		y := writeFile(t, "write/y.json", `{"name": "y"}`)
+		runTest(testCase{
+			args: []string{"--write", `name = $fileName`, x, y},
+		})(t)
+
+		for path, exp := range map[string]string{
+			x: "name: x.yaml\n",
+			y: "{\n    \"name\": \"y.json\"\n}\n",
+		} {
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error reading file: %v", err)
+			}
+			if string(got) != exp {
+				t.Errorf("expected file contents %q, got %q", exp, string(got))
+			}
+		}
+	})
+
+	t.Run("failed write leaves files untouched", func(t *testing.T) {
+		x := writeFile(t, "fail/x.yaml", "name: x\nage: 1\n")
+		y := writeFile(t, "fail/y.yaml", "name: y\n")
+		if _, _, err := runDasel([]string{"--write", `age = age + 1`, x, y}, nil); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		got, err := os.ReadFile(x)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+		if exp := "name: x\nage: 1\n"; string(got) != exp {
+			t.Errorf("expected file contents %q, got %q", exp, string(got))
+		}
+	})
+}
+
+func TestQueryDetectFormat(t *testing.T) {
+	t.Run("detect yaml from stdin", runTest(testCase{
+		args:   []string{"-o", "yaml", "name"},
+		in:     []byte("name: Tom\n"),
+		stdout: []byte("Tom\n"),
+	}))
+
+	t.Run("detect yaml with default output format", runTest(testCase{
+		args:   []string{"name"},
+		in:     []byte("name: Tom\n"),
+		stdout: []byte("\"Tom\"\n"),
+	}))
+
+	t.Run("auto", runTest(testCase{
+		args:   []string{"-i", "auto", "--root"},
+		in:     []byte("name = \"Tom\"\n\n[owner]\nage = 30\n"),
+		stdout: []byte("name = 'Tom'\n\n[owner]\nage = 30\n"),
+	}))
+
+	t.Run("detect from file contents", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data")
+		if err := os.WriteFile(path, []byte(`<a><b>1</b></a>`), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		runTest(testCase{
+			args:   []string{"-f", path, "-o", "json", "a.b"},
+			stdout: []byte("\"1\"\n"),
+		})(t)
+	})
+
+	t.Run("auto reports ambiguity", func(t *testing.T) {
+		_, _, err := runDasel([]string{"-i", "auto", "a"}, []byte("a = b\n"))
+		var ambiguous parsing.ErrAmbiguousFormat
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("expected ErrAmbiguousFormat, got %v", err)
+		}
+	})
+}
+
+func TestQueryLib(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "k8s.dsl")
+	if err := os.WriteFile(path, []byte(`$prefix = "app-"; def name(x) = $prefix + $x.toLower()`), 0600); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	t.Run("default alias", runTest(testCase{
+		args:   []string{"--lib", path, `k8s::name("API")`},
+		stdout: []byte("\"app-api\"\n"),
+	}))
+	t.Run("explicit alias", runTest(testCase{
+		args:   []string{"--lib", "kube=" + path, `$kube::prefix`},
+		stdout: []byte("\"app-\"\n"),
+	}))
+}
+
+func TestQueryLimits(t *testing.T) {
+	t.Run("max steps", func(t *testing.T) {
+		_, _, err := runDasel([]string{"--max-steps", "3", "[1, 2, 3].map($this + 1)"}, nil)
+		var maxSteps execution.ErrMaxStepsExceeded
+		if !errors.As(err, &maxSteps) {
+			t.Fatalf("expected ErrMaxStepsExceeded, got %v", err)
+		}
+	})
+	t.Run("within max steps", runTest(testCase{
+		args:   []string{"--max-steps", "100", "-o", "json", "--compact", "[1, 2, 3].map($this + 1)"},
+		stdout: []byte("[2,3,4]\n"),
+	}))
+	t.Run("timeout", func(t *testing.T) {
+		_, _, err := runDasel([]string{"--timeout", "1ns", "[1, 2, 3].map($this + 1)"}, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestQueryTrace(t *testing.T) {
+	stdout, stderr, err := runDasel([]string{"-i", "json", "--trace", "name"}, []byte(`{"name": "Tom"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(stdout) != "\"Tom\"\n" {
+		t.Errorf("expected output %q, got %q", "\"Tom\"\n", string(stdout))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(stderr), "\n"), "\n")
+	exp := []string{
+		`PropertyExpr <- {"name":"Tom"}`,
+		`  StringExpr "name" <- {"name":"Tom"}`,
+		`  -> "name" (`,
+		`-> "Tom" (`,
+	}
+	if len(lines) != len(exp) {
+		t.Fatalf("expected %d trace lines, got %d:\n%s", len(exp), len(lines), stderr)
+	}
+	for i, e := range exp {
+		if !strings.HasPrefix(lines[i], e) {
+			t.Errorf("expected line %d to start with %q, got %q", i, e, lines[i])
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/tomwright/dasel/v3/execution"
 	"github.com/tomwright/dasel/v3/model"
@@ -12,6 +16,7 @@ import (
 
 type runOpts struct {
 	Vars              variables
+	Libs              []string
 	ExtReadWriteFlags extReadWriteFlags
 	ExtReadFlags      extReadWriteFlags
 	ExtWriteFlags     extReadWriteFlags
@@ -20,7 +25,12 @@ type runOpts struct {
 	Compact           bool
 	ReturnRoot        bool
 	Unstable          bool
-	Query             string
+	Timeout           time.Duration
+	MaxSteps          int
+	// Trace is written to with each evaluated expression when set.
+	Trace       io.Writer
+	Query       string
+	ExecuteOpts []execution.ExecuteOptionFn
 
 	ConfigPath string
 
@@ -28,12 +38,25 @@ type runOpts struct {
 }
 
 func run(o runOpts) ([]byte, error) {
-	cfg, err := LoadConfig(o.ConfigPath)
+	o, err := resolveFormats(o)
 	if err != nil {
-		return nil, fmt.Errorf("error loading config: %w", err)
+		return nil, err
 	}
 
-	var opts []execution.ExecuteOptionFn
+	out, err := execute(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return write(o, out)
+}
+
+// resolveFormats fills in any missing input/output formats.
+func resolveFormats(o runOpts) (runOpts, error) {
+	cfg, err := LoadConfig(o.ConfigPath)
+	if err != nil {
+		return o, fmt.Errorf("error loading config: %w", err)
+	}
 
 	if o.OutFormat == "" && o.InFormat != "" {
 		o.OutFormat = o.InFormat
@@ -43,10 +66,48 @@ func run(o runOpts) ([]byte, error) {
 		o.OutFormat = cfg.DefaultFormat
 	}
 
+	return o, nil
+}
+
+// formatAuto is the input format used to request detection of the input format.
+const formatAuto = "auto"
+
+// resolveInFormat returns the input format to use for the given input data.
+// If inFormat is empty or auto, the format is detected from the filename and data.
+// If inFormat is empty and detection fails, fallback is used as long as it is a plausible format for the data.
+func resolveInFormat(inFormat string, fallback string, data []byte, filename string) (string, error) {
+	if inFormat != "" && inFormat != formatAuto {
+		return inFormat, nil
+	}
+
+	detected, err := parsing.DetectFormat(data, filename)
+	switch {
+	case err == nil:
+		return detected.String(), nil
+	case len(bytes.TrimSpace(data)) == 0:
+		// There's nothing to read, so the format doesn't matter.
+		return fallback, nil
+	case inFormat == formatAuto:
+		return "", err
+	}
+
+	var ambiguous parsing.ErrAmbiguousFormat
+	if errors.As(err, &ambiguous) && !slices.Contains(ambiguous.Formats, parsing.Format(fallback)) {
+		return "", err
+	}
+	return fallback, nil
+}
+
+// execute reads the input and executes the query against it.
+// The formats in the given options are expected to have been resolved.
+func execute(o runOpts) (*model.Value, error) {
+	var opts []execution.ExecuteOptionFn
+
 	readerOptions := parsing.DefaultReaderOptions()
 	applyReaderFlags(&readerOptions, o.ExtReadFlags, o.ExtReadWriteFlags)
 
 	var reader parsing.Reader
+	var err error
 	if len(o.InFormat) > 0 {
 		reader, err = parsing.Format(o.InFormat).NewReader(readerOptions)
 		if err != nil {
@@ -54,16 +115,15 @@ func run(o runOpts) ([]byte, error) {
 		}
 	}
 
-	writerOptions := parsing.DefaultWriterOptions()
-	writerOptions.Compact = o.Compact
-	applyWriterFlags(&writerOptions, o.ExtWriteFlags, o.ExtReadWriteFlags)
-
-	writer, err := parsing.Format(o.OutFormat).NewWriter(writerOptions)
+	cfg, err := LoadConfig(o.ConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get output writer: %w", err)
+		return nil, fmt.Errorf("error loading config: %w", err)
 	}
+	opts = append(opts, execution.WithLibPaths(cfg.LibPaths...))
 
 	opts = append(opts, variableOptions(o.Vars)...)
+	opts = append(opts, libOptions(o.Libs)...)
+	opts = append(opts, o.ExecuteOpts...)
 
 	// Default to null. If stdin is being read then this will be overwritten.
 	inputData := model.NewNullValue()
@@ -91,18 +151,45 @@ func run(o runOpts) ([]byte, error) {
 	if o.Unstable {
 		opts = append(opts, execution.WithUnstable())
 	}
+	if o.MaxSteps > 0 {
+		opts = append(opts, execution.WithMaxSteps(o.MaxSteps))
+	}
+	if o.Trace != nil {
+		opts = append(opts, execution.WithTracer(newTracer(o.Trace)))
+	}
+
+	ctx := context.Background()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
 
 	options := execution.NewOptions(opts...)
-	out, err := execution.ExecuteSelector(context.Background(), o.Query, inputData, options)
+	out, err := execution.ExecuteSelector(ctx, o.Query, inputData, options)
 	if err != nil {
-		return nil, err
+		return nil, selectorError{selector: o.Query, err: err}
 	}
 
 	if o.ReturnRoot {
 		out = inputData
 	}
 
-	outputBytes, err := writer.Write(out)
+	return out, nil
+}
+
+// write writes the given value using the output format in the given options.
+func write(o runOpts, value *model.Value) ([]byte, error) {
+	writerOptions := parsing.DefaultWriterOptions()
+	writerOptions.Compact = o.Compact
+	applyWriterFlags(&writerOptions, o.ExtWriteFlags, o.ExtReadWriteFlags)
+
+	writer, err := parsing.Format(o.OutFormat).NewWriter(writerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output writer: %w", err)
+	}
+
+	outputBytes, err := writer.Write(value)
 	if err != nil {
 		return nil, fmt.Errorf("error writing output: %w", err)
 	}
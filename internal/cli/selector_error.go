@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// maxHintKeys is the maximum number of available keys listed when a key is not found.
+const maxHintKeys = 10
+
+// selectorError is returned when a selector fails to parse or execute.
+// It keeps hold of the selector so that the failing part of it can be shown.
+type selectorError struct {
+	selector string
+	err      error
+}
+
+// Error returns the error message.
+// Execution errors are reported using the message of the innermost expression that failed,
+// rather than the message of every expression it was nested within.
+func (e selectorError) Error() string {
+	var execErr execution.ExecutionError
+	if !errors.As(e.err, &execErr) {
+		return e.err.Error()
+	}
+	for {
+		var inner execution.ExecutionError
+		if !errors.As(execErr.Err, &inner) {
+			break
+		}
+		execErr = inner
+	}
+	return fmt.Sprintf("error executing selector: %s", execErr.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e selectorError) Unwrap() error {
+	return e.err
+}
+
+// detail returns the line of the selector that caused the error with the failing
+// part underlined, followed by a hint on how to fix it.
+// An empty string is returned if neither are known.
+func (e selectorError) detail() string {
+	buf := new(strings.Builder)
+	if span, ok := errorSpan(e.err); ok {
+		buf.WriteString(underline(e.selector, span))
+	}
+	if hint := errorHint(e.err); hint != "" {
+		fmt.Fprintf(buf, "hint: %s\n", hint)
+	}
+	return buf.String()
+}
+
+// errorSpan returns the innermost span of the selector that the error relates to.
+// Errors within imported files are not considered, since their spans relate to a different source.
+func errorSpan(err error) (lexer.Span, bool) {
+	var span lexer.Span
+	var found bool
+	for ; err != nil; err = errors.Unwrap(err) {
+		if _, ok := err.(execution.ModuleError); ok {
+			break
+		}
+		if spanErr, ok := err.(lexer.SpanError); ok {
+			if s, ok := spanErr.Span(); ok {
+				span, found = s, true
+			}
+		}
+	}
+	return span, found
+}
+
+// underline returns the line of the selector containing the span, with the span underlined.
+func underline(selector string, span lexer.Span) string {
+	pos := min(max(span.Pos, 0), len(selector))
+	start := strings.LastIndexByte(selector[:pos], '\n') + 1
+	end := len(selector)
+	if i := strings.IndexByte(selector[pos:], '\n'); i >= 0 {
+		end = pos + i
+	}
+	indent := utf8.RuneCountInString(selector[start:pos])
+	width := max(utf8.RuneCountInString(selector[pos:pos+min(span.Len, end-pos)]), 1)
+	return fmt.Sprintf("  %s\n  %s%s\n", selector[start:end], strings.Repeat(" ", indent), strings.Repeat("^", width))
+}
+
+// errorHint returns a short suggestion on how to fix the error, if there is one.
+func errorHint(err error) string {
+	var unknownFunc execution.ErrUnknownFunction
+	var notFound model.MapKeyNotFound
+	var incompatible model.ErrIncompatibleTypes
+	var unexpected model.ErrUnexpectedType
+	var module execution.ModuleError
+	switch {
+	case errors.As(err, &unknownFunc):
+		if unknownFunc.Suggestion != "" {
+			return fmt.Sprintf("did you mean %q?", unknownFunc.Suggestion)
+		}
+	case errors.As(err, &notFound):
+		switch {
+		case len(notFound.Keys) == 0:
+			return "the map has no keys"
+		case len(notFound.Keys) > maxHintKeys:
+			return fmt.Sprintf("available keys: %s, ...", strings.Join(notFound.Keys[:maxHintKeys], ", "))
+		default:
+			return fmt.Sprintf("available keys: %s", strings.Join(notFound.Keys, ", "))
+		}
+	case errors.As(err, &incompatible):
+		return fmt.Sprintf("%s and %s values cannot be combined, convert one of them first, e.g. with toString or toInt", incompatible.A.Type(), incompatible.B.Type())
+	case errors.As(err, &unexpected):
+		return fmt.Sprintf("expected a %s value but got %s", unexpected.Expected, unexpected.Actual)
+	case errors.As(err, &module):
+		return fmt.Sprintf("the error occurred within %s", module.Path)
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+)
+
+func TestSelectorError_detail(t *testing.T) {
+	run := func(selector string, in *model.Value, exp string) func(t *testing.T) {
+		return func(t *testing.T) {
+			_, err := execution.ExecuteSelector(context.Background(), selector, in, execution.NewOptions())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			got := selectorError{selector: selector, err: err}.detail()
+			if got != exp {
+				t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+			}
+		}
+	}
+
+	users := func() *model.Value {
+		v := model.NewMapValue()
+		_ = v.SetMapKey("name", model.NewStringValue("Tom"))
+		_ = v.SetMapKey("age", model.NewIntValue(30))
+		return v
+	}
+
+	t.Run("missing key", run(`name.first`, model.NewMapValue(), "  name.first\n  ^^^^\nhint: the map has no keys\n"))
+	t.Run("missing key with available keys", run(`nme`, users(), "  nme\n  ^^^\nhint: available keys: name, age\n"))
+	t.Run("unknown function", run(`name.toUpperr()`, users(), "  name.toUpperr()\n       ^^^^^^^^\nhint: did you mean \"toUpper\"?\n"))
+	t.Run("type mismatch", run(`name + age`, users(), "  name + age\n       ^\nhint: string and int values cannot be combined, convert one of them first, e.g. with toString or toInt\n"))
+	t.Run("parse error", run(`name ]`, users(), "  name ]\n       ^\n"))
+	t.Run("multi line selector", run("$x = 1;\n$y", users(), "  $y\n  ^^\n"))
+
+	t.Run("no span or hint", func(t *testing.T) {
+		got := selectorError{selector: "foo", err: errors.New("boom")}.detail()
+		if got != "" {
+			t.Errorf("expected no detail, got %q", got)
+		}
+	})
+}
+
+func TestSelectorError_Error(t *testing.T) {
+	run := func(selector string, exp string) func(t *testing.T) {
+		return func(t *testing.T) {
+			_, err := execution.ExecuteSelector(context.Background(), selector, model.NewMapValue(), execution.NewOptions())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			got := selectorError{selector: selector, err: err}.Error()
+			if got != exp {
+				t.Errorf("expected %q, got %q", exp, got)
+			}
+		}
+	}
+
+	t.Run("innermost execution error", run(`[1].map(name.first)`, `error executing selector: unexpected type: expected map, got int`))
+	t.Run("function error", run(`len()`, `error executing selector: error executing function: func "len" expects exactly 1 arguments, got 0`))
+	t.Run("parse error", run(`name ]`, `error parsing selector: failed to parse: unexpected token 5 "]" at position 5.`))
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/internal/valuestr"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+// newTracer returns a tracer that writes each evaluated expression to w, indented by depth.
+// Each expression is written with its input when entered, and with its output and timing when exited.
+func newTracer(w io.Writer) execution.Tracer {
+	return func(ctx context.Context, event execution.TraceEvent) {
+		indent := strings.Repeat("  ", event.Depth)
+		switch event.Kind {
+		case execution.TraceEnter:
+			_, _ = fmt.Fprintf(w, "%s%s <- %s\n", indent, ast.Describe(event.Expr), valuestr.Compact(event.Input))
+		case execution.TraceExit:
+			if event.Err != nil {
+				_, _ = fmt.Fprintf(w, "%s-> error: %s (%s)\n", indent, event.Err, event.Duration)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "%s-> %s (%s)\n", indent, valuestr.Compact(event.Output), event.Duration)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+type ValidateCmd struct {
+	ExtReadFlags extReadWriteFlags `flag:"" name:"read-flag" help:"Reader flag to customise parsing. E.g. --read-flag xml-mode=structured"`
+	Schema       string            `flag:"" name:"schema" short:"s" required:"" help:"The JSON Schema (draft 2020-12) to validate against. May be in any supported format."`
+	SchemaFormat string            `flag:"" name:"schema-in" help:"The format of the schema file. Detected from the file extension or contents when omitted."`
+	InFormat     string            `flag:"" name:"in" short:"i" help:"The format of the input data. Detected from the file extension or contents when omitted or set to auto."`
+
+	ConfigPath string `name:"config" short:"c" help:"Path to config file" default:"~/dasel.yaml"`
+
+	Files []string `arg:"" name:"files" help:"Files or glob patterns to validate. Reads from stdin when omitted." optional:""`
+}
+
+// Run validates each of the given files, or stdin if no files are given.
+// Every failure is written to stdout. An exitError with code 1 is returned when any input is invalid,
+// and with code 2 if validation could not be performed.
+func (c *ValidateCmd) Run(ctx *Globals) error {
+	valid, err := c.run(ctx)
+	if err != nil {
+		return exitError{code: 2, err: err}
+	}
+	if !valid {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+func (c *ValidateCmd) run(ctx *Globals) (bool, error) {
+	cfg, err := LoadConfig(c.ConfigPath)
+	if err != nil {
+		return false, err
+	}
+
+	files, err := expandFilePatterns(c.Files)
+	if err != nil {
+		return false, err
+	}
+
+	readerOptions := parsing.DefaultReaderOptions()
+	applyReaderFlags(&readerOptions, c.ExtReadFlags, nil)
+	schema, err := readValueFile(c.Schema, c.SchemaFormat, readerOptions)
+	if err != nil {
+		return false, fmt.Errorf("error reading schema: %w", err)
+	}
+
+	if len(files) == 0 {
+		var input []byte
+		if ctx.Stdin != nil {
+			input, err = io.ReadAll(ctx.Stdin)
+			if err != nil {
+				return false, fmt.Errorf("error reading stdin: %w", err)
+			}
+		}
+		return c.validate(ctx, cfg, schema, input, "")
+	}
+
+	allValid := true
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("error reading file %q: %w", file, err)
+		}
+		valid, err := c.validate(ctx, cfg, schema, contents, file)
+		if err != nil {
+			return false, fmt.Errorf("error validating file %q: %w", file, err)
+		}
+		allValid = allValid && valid
+	}
+	return allValid, nil
+}
+
+// validate validates the input against the schema, writing any failures to stdout.
+// Failures are prefixed with the filename if one is given.
+func (c *ValidateCmd) validate(ctx *Globals, cfg Config, schema *model.Value, input []byte, filename string) (bool, error) {
+	inFormat, err := resolveInFormat(c.InFormat, cfg.DefaultFormat, input, filename)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := execute(runOpts{
+		ExtReadFlags: c.ExtReadFlags,
+		InFormat:     inFormat,
+		Stdin:        bytes.NewReader(input),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = execution.ValidateSchema(value, schema)
+	var validationErr execution.ErrSchemaValidation
+	if !errors.As(err, &validationErr) {
+		return err == nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	for _, e := range validationErr.Errors {
+		if filename != "" {
+			_, _ = fmt.Fprintf(buf, "%s: ", filename)
+		}
+		_, _ = fmt.Fprintf(buf, "%s\n", e.Error())
+	}
+	if _, err := ctx.Stdout.Write(buf.Bytes()); err != nil {
+		return false, fmt.Errorf("error writing output: %w", err)
+	}
+	return false, nil
+}
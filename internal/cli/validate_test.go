@@ -0,0 +1,82 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(t *testing.T, name string, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+		return path
+	}
+
+	schema := writeFile(t, "schema.json", `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string"},
+		"replicas": {"$ref": "#/$defs/replicas"}
+	},
+	"$defs": {
+		"replicas": {"type": "integer", "minimum": 1}
+	}
+}`)
+	valid := writeFile(t, "valid.yaml", "name: app\nreplicas: 2\n")
+	invalid := writeFile(t, "invalid.toml", "replicas = 0\n")
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"validate", "--schema", schema, valid}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no output, got %s", string(got))
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"validate", "--schema", schema, valid, invalid}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		exp := invalid + `: $root: missing required property "name"
+` + invalid + `: replicas: value 0 must be >= 1
+`
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		got, err := runDaselCommand([]string{"validate", "-s", schema, "-i", "json"}, []byte(`{"name": 1}`))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		exp := "name: expected string, got integer\n"
+		if string(got) != exp {
+			t.Errorf("expected:\n%s\ngot:\n%s", exp, string(got))
+		}
+	})
+
+	t.Run("yaml schema", func(t *testing.T) {
+		yamlSchema := writeFile(t, "schema.yaml", "type: object\nrequired: [name]\n")
+		_, err := runDaselCommand([]string{"validate", "--schema", yamlSchema, valid}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("missing schema", func(t *testing.T) {
+		_, err := runDaselCommand([]string{"validate", "--schema", filepath.Join(dir, "missing.json"), valid}, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
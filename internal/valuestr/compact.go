@@ -0,0 +1,25 @@
+package valuestr
+
+import (
+	"strings"
+
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+// Compact returns a single line representation of the value for use in messages and logs.
+// Values are written as compact JSON when the json format is registered, and otherwise as
+// the value's String form with all whitespace collapsed.
+func Compact(v *model.Value) string {
+	if v == nil {
+		return "<nil>"
+	}
+	opts := parsing.DefaultWriterOptions()
+	opts.Compact = true
+	if writer, err := parsing.Format("json").NewWriter(opts); err == nil {
+		if b, err := writer.Write(v); err == nil {
+			return strings.TrimSpace(string(b))
+		}
+	}
+	return strings.Join(strings.Fields(v.String()), " ")
+}
@@ -0,0 +1,29 @@
+package valuestr_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/internal/valuestr"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+	_ "github.com/tomwright/dasel/v3/parsing/json"
+)
+
+func TestCompact(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   *model.Value
+		exp  string
+	}{
+		{name: "nil", in: nil, exp: "<nil>"},
+		{name: "string", in: model.NewStringValue("a b"), exp: `"a b"`},
+		{name: "map", in: model.NewValue(orderedmap.NewMap().Set("a", []any{int64(1), int64(2)})), exp: `{"a":[1,2]}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := valuestr.Compact(tc.in); got != tc.exp {
+				t.Errorf("expected %s, got %s", tc.exp, got)
+			}
+		})
+	}
+}
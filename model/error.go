@@ -5,6 +5,8 @@ import "fmt"
 // MapKeyNotFound is returned when a key is not found in a map.
 type MapKeyNotFound struct {
 	Key string
+	// Keys are the keys that exist in the map, when known.
+	Keys []string
 }
 
 // Error returns the error message.
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structField is an exported field of a struct, as it appears as a map key.
+type structField struct {
+	name string
+	// index is the sequence of field indexes to reach the field, including any embedded structs.
+	index     []int
+	omitEmpty bool
+}
+
+var structFieldsCache sync.Map
+
+// structFields returns the fields of the struct type t, in the order they are declared.
+// Field names are read from the dasel tag, falling back to the json tag, the yaml tag and then the Go field name.
+// Fields tagged "-" are skipped. Fields of embedded structs without a tag name are promoted into the parent,
+// and fields nearer the parent take precedence over promoted fields with the same name.
+func structFields(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	var fields []structField
+	depths := map[string]int{}
+	positions := map[string]int{}
+
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			fieldIndex := append(append([]int{}, index...), i)
+
+			name, opts := structTag(f)
+			if name == "-" && opts == "" {
+				continue
+			}
+
+			if f.Anonymous && name == "" {
+				embedded := f.Type
+				if embedded.Kind() == reflect.Pointer {
+					if !f.IsExported() {
+						// Unexported pointers cannot be allocated when setting a promoted field.
+						continue
+					}
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					walk(embedded, fieldIndex)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+
+			if name == "" {
+				name = f.Name
+			}
+			field := structField{
+				name:      name,
+				index:     fieldIndex,
+				omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			}
+
+			depth := len(fieldIndex)
+			if existing, ok := positions[name]; ok {
+				if depths[name] <= depth {
+					continue
+				}
+				fields[existing] = field
+				depths[name] = depth
+				continue
+			}
+			positions[name] = len(fields)
+			depths[name] = depth
+			fields = append(fields, field)
+		}
+	}
+	walk(t, nil)
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// structTag returns the name and options from the dasel, json or yaml tag of the field.
+func structTag(f reflect.StructField) (string, string) {
+	for _, key := range []string{"dasel", "json", "yaml"} {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			name, opts, _ := strings.Cut(tag, ",")
+			return name, opts
+		}
+	}
+	return "", ""
+}
+
+// structFieldByName returns the field with the given name.
+func structFieldByName(fields []structField, name string) (structField, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// lookupStructField returns the field with the given name.
+// An exact match is preferred, otherwise the name is matched case-insensitively.
+func lookupStructField(fields []structField, name string) (structField, bool) {
+	if f, ok := structFieldByName(fields, name); ok {
+		return f, true
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
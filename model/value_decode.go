@@ -0,0 +1,210 @@
+package model
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// DecodeError is returned by Decode when a value cannot be decoded into the target.
+type DecodeError struct {
+	// Path is the location of the value within the decoded value, e.g. users[1].age.
+	// It is empty for the decoded value itself.
+	Path string
+	// Type is the Go type that was being decoded into.
+	Type reflect.Type
+	// Actual is the type of the value.
+	Actual Type
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// Error returns the error message.
+func (e DecodeError) Error() string {
+	msg := fmt.Sprintf("cannot decode %s into %s", e.Actual, e.Type)
+	if e.Path != "" {
+		msg += " at " + e.Path
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	valueType           = reflect.TypeFor[*Value]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Decode populates the Go value pointed to by target with the value.
+//
+// Maps are decoded into structs and string keyed maps, and arrays into slices.
+// Struct fields are matched by the name in their dasel tag, falling back to the json tag, the yaml tag and then the field name.
+// Names are matched exactly where possible, otherwise case-insensitively. Map keys without a matching field are ignored.
+// Ints may be decoded into floats, strings into types that implement encoding.TextUnmarshaler,
+// and any value into an empty interface or *Value. Null decodes to the zero value.
+func (v *Value) Decode(target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", target)
+	}
+	return v.decode(rv.Elem(), "")
+}
+
+func (v *Value) decode(rv reflect.Value, path string) error {
+	t := rv.Type()
+	if t == valueType {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	if v.IsNull() {
+		rv.SetZero()
+		return nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(t.Elem()))
+		}
+		return v.decode(rv.Elem(), path)
+	}
+
+	mismatch := func(err error) error {
+		return DecodeError{Path: path, Type: t, Actual: v.Type(), Err: err}
+	}
+
+	if v.IsString() && reflect.PointerTo(t).Implements(textUnmarshalerType) && rv.CanAddr() {
+		s, err := v.StringValue()
+		if err != nil {
+			return mismatch(err)
+		}
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return mismatch(err)
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if t.NumMethod() > 0 {
+			return mismatch(nil)
+		}
+		goValue, err := v.GoValue()
+		if err != nil {
+			return mismatch(err)
+		}
+		rv.Set(reflect.ValueOf(goValue))
+	case reflect.String:
+		s, err := v.StringValue()
+		if err != nil {
+			return mismatch(nil)
+		}
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := v.BoolValue()
+		if err != nil {
+			return mismatch(nil)
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := v.IntValue()
+		if err != nil {
+			return mismatch(nil)
+		}
+		if rv.OverflowInt(i) {
+			return mismatch(fmt.Errorf("%d overflows %s", i, t))
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := v.IntValue()
+		if err != nil {
+			return mismatch(nil)
+		}
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return mismatch(fmt.Errorf("%d overflows %s", i, t))
+		}
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch {
+		case v.IsFloat():
+			f, _ = v.FloatValue()
+		case v.IsInt():
+			i, _ := v.IntValue()
+			f = float64(i)
+		default:
+			return mismatch(nil)
+		}
+		if rv.OverflowFloat(f) {
+			return mismatch(fmt.Errorf("%g overflows %s", f, t))
+		}
+		rv.SetFloat(f)
+	case reflect.Slice:
+		if !v.IsSlice() {
+			return mismatch(nil)
+		}
+		l, err := v.SliceLen()
+		if err != nil {
+			return mismatch(err)
+		}
+		res := reflect.MakeSlice(t, l, l)
+		if err := v.RangeSlice(func(i int, item *Value) error {
+			return item.decode(res.Index(i), path+"["+strconv.Itoa(i)+"]")
+		}); err != nil {
+			return err
+		}
+		rv.Set(res)
+	case reflect.Map:
+		if !v.IsMap() {
+			return mismatch(nil)
+		}
+		if t.Key().Kind() != reflect.String {
+			return mismatch(fmt.Errorf("map keys must be strings"))
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(t))
+		}
+		if err := v.RangeMap(func(key string, item *Value) error {
+			elem := reflect.New(t.Elem()).Elem()
+			if err := item.decode(elem, decodeKeyPath(path, key)); err != nil {
+				return err
+			}
+			rv.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+			return nil
+		}); err != nil {
+			return err
+		}
+	case reflect.Struct:
+		if !v.IsMap() {
+			return mismatch(nil)
+		}
+		fields := structFields(t)
+		if err := v.RangeMap(func(key string, item *Value) error {
+			field, ok := lookupStructField(fields, key)
+			if !ok {
+				return nil
+			}
+			return item.decode(fieldByIndexAlloc(rv, field.index), decodeKeyPath(path, key))
+		}); err != nil {
+			return err
+		}
+	default:
+		return mismatch(fmt.Errorf("unsupported type"))
+	}
+	return nil
+}
+
+// decodeKeyPath returns the path to the given key within the map at path.
+func decodeKeyPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,177 @@
+package model_test
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tomwright/dasel/v3/model"
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+type decodeAddress struct {
+	City string `dasel:"city"`
+	Zip  string `json:"postcode,omitempty"`
+}
+
+type decodeMeta struct {
+	Created string `json:"created"`
+}
+
+type decodeUser struct {
+	decodeMeta
+	Name     string            `dasel:"name,omitempty" json:"fullName"`
+	Age      int               `json:"age"`
+	Score    float64           `json:"score"`
+	Admin    bool              `json:"admin"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Address  *decodeAddress    `json:"address"`
+	Extra    any               `json:"extra"`
+	Raw      *model.Value      `json:"raw"`
+	IP       netip.Addr        `json:"ip"`
+	Ignored  string            `json:"-"`
+	Nickname string
+}
+
+func TestValue_Decode(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		in := model.NewValue(orderedmap.NewMap().
+			Set("name", "Tom").
+			Set("age", int64(31)).
+			Set("score", int64(7)).
+			Set("admin", true).
+			Set("tags", []any{"a", "b"}).
+			Set("labels", orderedmap.NewMap().Set("env", "prod")).
+			Set("address", orderedmap.NewMap().Set("city", "London").Set("postcode", "N1")).
+			Set("extra", orderedmap.NewMap().Set("x", int64(1))).
+			Set("raw", "raw").
+			Set("ip", "10.0.0.1").
+			Set("created", "today").
+			Set("Ignored", "x").
+			Set("nickname", "tw").
+			Set("unknown", "x"))
+
+		var got decodeUser
+		if err := in.Decode(&got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		rawStr, err := got.Raw.StringValue()
+		if err != nil || rawStr != "raw" {
+			t.Errorf("unexpected raw value: %v, %v", rawStr, err)
+		}
+		got.Raw = nil
+
+		exp := decodeUser{
+			decodeMeta: decodeMeta{Created: "today"},
+			Name:       "Tom",
+			Age:        31,
+			Score:      7,
+			Admin:      true,
+			Tags:       []string{"a", "b"},
+			Labels:     map[string]string{"env": "prod"},
+			Address:    &decodeAddress{City: "London", Zip: "N1"},
+			Extra:      map[string]any{"x": int64(1)},
+			IP:         netip.MustParseAddr("10.0.0.1"),
+			Nickname:   "tw",
+		}
+		opts := []cmp.Option{
+			cmp.AllowUnexported(decodeUser{}),
+			cmp.Comparer(func(a, b netip.Addr) bool { return a == b }),
+		}
+		if !cmp.Equal(exp, got, opts...) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, got, opts...))
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		var got []int
+		if err := model.NewValue([]any{int64(1), int64(2)}).Decode(&got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exp := []int{1, 2}; !cmp.Equal(exp, got) {
+			t.Errorf("unexpected result: %s", cmp.Diff(exp, got))
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		got := "x"
+		if err := model.NewNullValue().Decode(&got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "" {
+			t.Errorf("expected zero value, got %q", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			in     *model.Value
+			target any
+			path   string
+			typ    reflect.Type
+			actual model.Type
+			msg    string
+		}{
+			{
+				name:   "root",
+				in:     model.NewStringValue("x"),
+				target: new(int),
+				typ:    reflect.TypeFor[int](),
+				actual: model.TypeString,
+				msg:    "cannot decode string into int",
+			},
+			{
+				name: "nested",
+				in: model.NewValue(orderedmap.NewMap().
+					Set("users", []any{
+						orderedmap.NewMap().Set("age", int64(1)),
+						orderedmap.NewMap().Set("age", "old"),
+					})),
+				target: new(struct {
+					Users []struct {
+						Age int `json:"age"`
+					} `json:"users"`
+				}),
+				path:   "users[1].age",
+				typ:    reflect.TypeFor[int](),
+				actual: model.TypeString,
+				msg:    "cannot decode string into int at users[1].age",
+			},
+			{
+				name:   "overflow",
+				in:     model.NewIntValue(300),
+				target: new(uint8),
+				typ:    reflect.TypeFor[uint8](),
+				actual: model.TypeInt,
+				msg:    "cannot decode int into uint8: 300 overflows uint8",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.in.Decode(tc.target)
+				var decodeErr model.DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("expected DecodeError, got %v", err)
+				}
+				if decodeErr.Path != tc.path || decodeErr.Type != tc.typ || decodeErr.Actual != tc.actual {
+					t.Errorf("unexpected error: %+v", decodeErr)
+				}
+				if err.Error() != tc.msg {
+					t.Errorf("expected message %q, got %q", tc.msg, err.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("target must be a pointer", func(t *testing.T) {
+		var got string
+		if err := model.NewStringValue("x").Decode(got); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -41,12 +41,16 @@ func (v *Value) IsString() bool {
 }
 
 func (v *Value) isString() bool {
-	return v.value.Kind() == reflect.String
+	return v.value.Kind() == reflect.String || v.isTextMarshaler()
 }
 
 // StringValue returns the string value of the Value.
+// Structs that implement encoding.TextMarshaler, such as time.Time, are returned as their text.
 func (v *Value) StringValue() (string, error) {
 	unpacked := v.UnpackKinds(reflect.Pointer, reflect.Interface)
+	if unpacked.isTextMarshaler() {
+		return unpacked.textValue()
+	}
 	if !unpacked.isString() {
 		return "", ErrUnexpectedType{
 			Expected: TypeString,
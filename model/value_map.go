@@ -14,8 +14,9 @@ func NewMapValue() *Value {
 }
 
 // IsMap returns true if the value is a map.
+// Structs are treated as maps of their exported fields.
 func (v *Value) IsMap() bool {
-	return v.isStandardMap() || v.isDencodingMap()
+	return v.isStandardMap() || v.isDencodingMap() || v.isStruct()
 }
 
 func (v *Value) isStandardMap() bool {
@@ -38,6 +39,7 @@ func (v *Value) dencodingMapValue() (*orderedmap.Map, error) {
 }
 
 // SetMapKey sets the value at the specified key in the map.
+// For structs the value is decoded into the field with the key as its name.
 func (v *Value) SetMapKey(key string, value *Value) error {
 	switch {
 	case v.isDencodingMap():
@@ -54,6 +56,8 @@ func (v *Value) SetMapKey(key string, value *Value) error {
 		}
 		unpacked.value.SetMapIndex(reflect.ValueOf(key), value.value)
 		return nil
+	case v.isStruct():
+		return v.setStructKey(key, value)
 	default:
 		return fmt.Errorf("value is not a map")
 	}
@@ -73,7 +77,12 @@ func (v *Value) MapCopy() (*Value, error) {
 	return res, nil
 }
 
+// MapKeyExists returns true if the key exists in the map.
+// Struct fields that are left out of MapKeys are reported as missing.
 func (v *Value) MapKeyExists(key string) (bool, error) {
+	if v.isStruct() {
+		return v.structKeyExists(key)
+	}
 	_, err := v.GetMapKey(key)
 	if err != nil && !errors.As(err, &MapKeyNotFound{}) {
 		return false, err
@@ -125,6 +134,8 @@ func (v *Value) GetMapKey(key string) (*Value, error) {
 			return nil
 		}
 		return res, nil
+	case v.isStruct():
+		return v.getStructKey(key)
 	default:
 		return nil, ErrUnexpectedType{
 			Expected: TypeMap,
@@ -134,6 +145,7 @@ func (v *Value) GetMapKey(key string) (*Value, error) {
 }
 
 // DeleteMapKey deletes the key from the map.
+// Struct fields are reset to their zero value.
 func (v *Value) DeleteMapKey(key string) error {
 	switch {
 	case v.isDencodingMap():
@@ -150,6 +162,9 @@ func (v *Value) DeleteMapKey(key string) error {
 		}
 		unpacked.value.SetMapIndex(reflect.ValueOf(key), reflect.Value{})
 		return nil
+	case v.isStruct():
+		// Struct fields cannot be removed, so they are reset to their zero value instead.
+		return v.setStructKey(key, NewNullValue())
 	default:
 		return ErrUnexpectedType{
 			Expected: TypeMap,
@@ -159,6 +174,7 @@ func (v *Value) DeleteMapKey(key string) error {
 }
 
 // MapKeys returns a list of keys in the map.
+// For structs these are the field names, in the order they are declared.
 func (v *Value) MapKeys() ([]string, error) {
 	switch {
 	case v.isDencodingMap():
@@ -178,6 +194,8 @@ func (v *Value) MapKeys() ([]string, error) {
 			strKeys[i] = k.String()
 		}
 		return strKeys, nil
+	case v.isStruct():
+		return v.structMapKeys()
 	default:
 		return nil, ErrUnexpectedType{
 			Expected: TypeMap,
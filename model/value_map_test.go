@@ -2,7 +2,10 @@ package model_test
 
 import (
 	"errors"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/tomwright/dasel/v3/model"
 	"github.com/tomwright/dasel/v3/model/orderedmap"
@@ -212,3 +215,179 @@ func TestMapKeyValues(t *testing.T) {
 		t.Errorf("unexpected keys: %s, %s", kvs[0].Key, kvs[1].Key)
 	}
 }
+
+type structMapBase struct {
+	ID string `yaml:"id"`
+}
+
+type structMapServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type structMapConfig struct {
+	structMapBase
+	Name    string          `dasel:"name" json:"fullName"`
+	Server  structMapServer `json:"server"`
+	Tags    []string        `json:"tags,omitempty"`
+	Secret  string          `json:"-"`
+	Enabled bool
+	hidden  string
+}
+
+func TestStructMap(t *testing.T) {
+	config := func() *structMapConfig {
+		return &structMapConfig{
+			structMapBase: structMapBase{ID: "1"},
+			Name:          "app",
+			Server:        structMapServer{Host: "localhost", Port: 80},
+			Secret:        "secret",
+			hidden:        "hidden",
+		}
+	}
+
+	t.Run("IsMap", func(t *testing.T) {
+		if v := model.NewValue(config()); !v.IsMap() || v.Type() != model.TypeMap {
+			t.Errorf("expected struct to be a map, got %s", v.Type())
+		}
+		if v := model.NewValue(time.Now()); v.IsMap() || v.Type() != model.TypeString {
+			t.Errorf("expected time.Time to be a string, got %s", v.Type())
+		}
+	})
+
+	t.Run("MapKeys", func(t *testing.T) {
+		keys, err := model.NewValue(config()).MapKeys()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		exp := []string{"id", "name", "server", "Enabled"}
+		if !slices.Equal(exp, keys) {
+			t.Errorf("expected keys %v, got %v", exp, keys)
+		}
+	})
+
+	t.Run("GetMapKey", func(t *testing.T) {
+		v := model.NewValue(config())
+		server, err := v.GetMapKey("server")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		port, err := server.GetMapKey("port")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, err := port.IntValue(); err != nil || got != 80 {
+			t.Errorf("expected 80, got %d, %v", got, err)
+		}
+		if _, err := v.GetMapKey("Secret"); !errors.As(err, &model.MapKeyNotFound{}) {
+			t.Errorf("expected key not found error, got %v", err)
+		}
+	})
+
+	t.Run("SetMapKey", func(t *testing.T) {
+		c := config()
+		v := model.NewValue(c)
+		if err := v.SetMapKey("id", model.NewStringValue("2")); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := v.SetMapKey("tags", model.NewValue([]any{"a"})); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if c.ID != "2" || !slices.Equal(c.Tags, []string{"a"}) {
+			t.Errorf("unexpected struct: %+v", c)
+		}
+		if err := v.SetMapKey("missing", model.NewStringValue("x")); err == nil {
+			t.Errorf("expected error setting unknown key")
+		}
+		if err := v.SetMapKey("name", model.NewIntValue(1)); err == nil {
+			t.Errorf("expected error setting mismatched type")
+		}
+	})
+
+	t.Run("Set nested value", func(t *testing.T) {
+		c := config()
+		server, err := model.NewValue(c).GetMapKey("server")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		port, err := server.GetMapKey("port")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := port.Set(model.NewIntValue(8080)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if c.Server.Port != 8080 {
+			t.Errorf("expected port 8080, got %d", c.Server.Port)
+		}
+	})
+
+	t.Run("SetMapKey on a struct value", func(t *testing.T) {
+		v := model.NewValue(*config())
+		if err := v.SetMapKey("name", model.NewStringValue("other")); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		name, err := v.GetMapKey("name")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, _ := name.StringValue(); got != "other" {
+			t.Errorf("expected other, got %s", got)
+		}
+	})
+
+	t.Run("MapKeyExists", func(t *testing.T) {
+		c := config()
+		v := model.NewValue(c)
+		for key, exp := range map[string]bool{"name": true, "tags": false, "Secret": false, "missing": false} {
+			if got, err := v.MapKeyExists(key); err != nil || got != exp {
+				t.Errorf("expected %s to exist %v, got %v, %v", key, exp, got, err)
+			}
+		}
+		c.Tags = []string{"a"}
+		if got, _ := v.MapKeyExists("tags"); !got {
+			t.Errorf("expected tags to exist once set")
+		}
+	})
+
+	t.Run("time field", func(t *testing.T) {
+		type event struct {
+			At time.Time `json:"at"`
+		}
+		e := &event{At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+		v := model.NewValue(e)
+		at, err := v.GetMapKey("at")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, err := at.StringValue(); err != nil || got != "2024-01-02T03:04:05Z" {
+			t.Errorf("expected 2024-01-02T03:04:05Z, got %q, %v", got, err)
+		}
+		goValue, err := v.GoValue()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if exp := map[string]any{"at": "2024-01-02T03:04:05Z"}; !reflect.DeepEqual(exp, goValue) {
+			t.Errorf("expected %v, got %v", exp, goValue)
+		}
+		if err := at.Set(model.NewStringValue("2025-06-07T08:09:10Z")); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if exp := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC); !e.At.Equal(exp) {
+			t.Errorf("expected %v, got %v", exp, e.At)
+		}
+		if err := at.Set(model.NewStringValue("not a time")); err == nil {
+			t.Errorf("expected error setting invalid time")
+		}
+	})
+
+	t.Run("DeleteMapKey", func(t *testing.T) {
+		c := config()
+		if err := model.NewValue(c).DeleteMapKey("name"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if c.Name != "" {
+			t.Errorf("expected name to be reset, got %q", c.Name)
+		}
+	})
+}
@@ -36,6 +36,10 @@ func (v *Value) Set(newValue *Value) error {
 		return nil
 	}
 
+	if ok, err := a.setText(newValue); ok || err != nil {
+		return err
+	}
+
 	// These are commented out because I don't think they are needed.
 
 	//if a.Kind() == newValue.Kind() {
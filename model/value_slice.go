@@ -45,6 +45,15 @@ func (v *Value) Append(val *Value) error {
 	// Wrap the value to preserve metadata and set functions.
 	valToAppend := reflect.ValueOf(val)
 	newVal := reflect.Append(unpacked.value, valToAppend)
+	if !unpacked.value.CanSet() {
+		// Slices held in an interface are not addressable, so replace the containing value instead.
+		if err := v.Set(NewValue(newVal.Interface())); err != nil {
+			return err
+		}
+		// Keep this value in sync so that further changes apply to the new slice.
+		v.value = newVal
+		return nil
+	}
 	unpacked.value.Set(newVal)
 	return nil
 }
@@ -105,6 +114,36 @@ func (v *Value) SetSliceIndex(i int, val *Value) error {
 	return nil
 }
 
+// DeleteSliceIndex removes the value at the specified index in the slice.
+func (v *Value) DeleteSliceIndex(i int) error {
+	unpacked := v.UnpackKinds(reflect.Interface, reflect.Pointer)
+	if !unpacked.isSlice() {
+		return ErrUnexpectedType{
+			Expected: TypeSlice,
+			Actual:   v.Type(),
+		}
+	}
+	l := unpacked.value.Len()
+	if i < 0 || i >= l {
+		return SliceIndexOutOfRange{Index: i}
+	}
+	// Build a new slice rather than shifting elements in place, since other values may share the backing array.
+	newVal := reflect.MakeSlice(unpacked.value.Type(), 0, l-1)
+	newVal = reflect.AppendSlice(newVal, unpacked.value.Slice(0, i))
+	newVal = reflect.AppendSlice(newVal, unpacked.value.Slice(i+1, l))
+	if !unpacked.value.CanSet() {
+		// Slices held in an interface are not addressable, so replace the containing value instead.
+		if err := v.Set(NewValue(newVal.Interface())); err != nil {
+			return err
+		}
+		// Keep this value in sync so that further changes apply to the new slice.
+		v.value = newVal
+		return nil
+	}
+	unpacked.value.Set(newVal)
+	return nil
+}
+
 // RangeSlice iterates over each item in the slice and calls the provided function.
 func (v *Value) RangeSlice(f func(int, *Value) error) error {
 	length, err := v.SliceLen()
@@ -66,6 +66,37 @@ func TestSlice(t *testing.T) {
 					t.Errorf("expected baz, got %s", got)
 				}
 			})
+			t.Run("DeleteSliceIndex", func(t *testing.T) {
+				v := v()
+				if err := v.DeleteSliceIndex(0); err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				l, err := v.SliceLen()
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				if l != 1 {
+					t.Errorf("expected len of 1, got %d", l)
+				}
+				bar, err := v.GetSliceIndex(0)
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				got, err := bar.StringValue()
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				if got != "bar" {
+					t.Errorf("expected bar, got %s", got)
+				}
+				if err := v.DeleteSliceIndex(1); err == nil {
+					t.Errorf("expected out of range error")
+				}
+			})
 			t.Run("Len", func(t *testing.T) {
 				v := v()
 				got, err := v.SliceLen()
@@ -194,6 +225,17 @@ func TestSlice(t *testing.T) {
 
 	t.Run("standard slice", runTests(standardSlice))
 	t.Run("model slice", runTests(modelSlice))
+	t.Run("DeleteSliceIndex keeps shared slices", func(t *testing.T) {
+		shared := []any{"foo", "bar", "baz"}
+		v := model.NewValue(shared)
+		if err := v.DeleteSliceIndex(0); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		if shared[0] != "foo" || shared[1] != "bar" || shared[2] != "baz" {
+			t.Errorf("expected shared slice to be unchanged, got %v", shared)
+		}
+	})
 }
 
 func TestSliceIndexRange_Reverse(t *testing.T) {
@@ -254,3 +296,29 @@ func TestSlice_GetSliceIndex_OutOfRange(t *testing.T) {
 		t.Fatal("expected error for negative index")
 	}
 }
+
+func TestSlice_AppendNested(t *testing.T) {
+	m := model.NewValue(map[string]any{"items": []any{"foo"}})
+	items, err := m.GetMapKey("items")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := items.Append(model.NewValue("bar")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, v := range []*model.Value{items, func() *model.Value {
+		items, err := m.GetMapKey("items")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return items
+	}()} {
+		l, err := v.SliceLen()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if l != 2 {
+			t.Errorf("expected len of 2, got %d", l)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tomwright/dasel/v3/model/orderedmap"
+)
+
+// isStruct returns true if the value is a struct whose exported fields are treated as map keys.
+// Structs that marshal themselves as text, such as time.Time, are treated as strings instead.
+func (v *Value) isStruct() bool {
+	unpacked := v.UnpackKinds(reflect.Interface, reflect.Pointer)
+	if unpacked.Kind() != reflect.Struct {
+		return false
+	}
+	return unpacked.value.Type() != reflect.TypeFor[orderedmap.Map]() && !unpacked.isTextMarshaler()
+}
+
+func (v *Value) structValue() (reflect.Value, error) {
+	unpacked, err := v.UnpackUntilKind(reflect.Struct)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("error unpacking value: %w", err)
+	}
+	return unpacked.value, nil
+}
+
+// structMapKeys returns the names of the fields of the struct.
+// Fields tagged omitempty are skipped when they are empty, as are fields within nil embedded structs.
+func (v *Value) structMapKeys() ([]string, error) {
+	rv, err := v.structValue()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, field := range structFields(rv.Type()) {
+		if structFieldPresent(rv, field) {
+			keys = append(keys, field.name)
+		}
+	}
+	return keys, nil
+}
+
+// structKeyExists returns true if the key is one of the keys returned by structMapKeys.
+func (v *Value) structKeyExists(key string) (bool, error) {
+	rv, err := v.structValue()
+	if err != nil {
+		return false, err
+	}
+	field, ok := structFieldByName(structFields(rv.Type()), key)
+	return ok && structFieldPresent(rv, field), nil
+}
+
+// structFieldPresent returns false if the field is within a nil embedded struct, or is tagged omitempty and empty.
+func structFieldPresent(rv reflect.Value, field structField) bool {
+	fv, ok := fieldByIndex(rv, field.index)
+	return ok && !(field.omitEmpty && isEmptyValue(fv))
+}
+
+// getStructKey returns the value of the field with the given name.
+// Setting the returned value sets the field.
+func (v *Value) getStructKey(key string) (*Value, error) {
+	rv, err := v.structValue()
+	if err != nil {
+		return nil, err
+	}
+	field, ok := structFieldByName(structFields(rv.Type()), key)
+	if !ok {
+		return nil, MapKeyNotFound{Key: key}
+	}
+	fv, ok := fieldByIndex(rv, field.index)
+	if !ok {
+		return nil, MapKeyNotFound{Key: key}
+	}
+	res := NewValue(fv)
+	res.setFn = func(newValue *Value) error {
+		return v.SetMapKey(key, newValue)
+	}
+	return res, nil
+}
+
+// setStructKey decodes the value into the field with the given name.
+// Nil embedded structs are allocated as needed.
+func (v *Value) setStructKey(key string, value *Value) error {
+	rv, err := v.structValue()
+	if err != nil {
+		return err
+	}
+	field, ok := structFieldByName(structFields(rv.Type()), key)
+	if !ok {
+		return fmt.Errorf("struct %s has no field for key %q", rv.Type(), key)
+	}
+
+	if rv.CanSet() {
+		return value.decode(fieldByIndexAlloc(rv, field.index), key)
+	}
+
+	// Structs held in an interface are not addressable, so update a copy and replace the containing value instead.
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+	if err := value.decode(fieldByIndexAlloc(cp, field.index), key); err != nil {
+		return err
+	}
+	return v.Set(NewValue(cp.Interface()))
+}
+
+// fieldByIndex returns the nested field of the struct.
+// It returns false if the field is within a nil embedded struct.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// fieldByIndexAlloc returns the nested field of the struct, allocating any nil embedded struct pointers along the way.
+func fieldByIndexAlloc(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+// isEmptyValue returns true if the value is considered empty by omitempty.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return rv.IsZero()
+	default:
+		return false
+	}
+}
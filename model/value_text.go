@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isTextMarshaler returns true if the value is a struct that marshals itself as text, such as time.Time.
+// These values are treated as strings.
+func (v *Value) isTextMarshaler() bool {
+	if v.value.Kind() != reflect.Struct {
+		return false
+	}
+	t := v.value.Type()
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// textValue returns the text form of a value for which isTextMarshaler is true.
+func (v *Value) textValue() (string, error) {
+	rv := v.value
+	if !rv.Type().Implements(textMarshalerType) {
+		if !rv.CanAddr() {
+			cp := reflect.New(rv.Type())
+			cp.Elem().Set(rv)
+			rv = cp.Elem()
+		}
+		rv = rv.Addr()
+	}
+	text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", fmt.Errorf("error marshaling %s as text: %w", v.value.Type(), err)
+	}
+	return string(text), nil
+}
+
+// setText sets an addressable value that implements encoding.TextUnmarshaler from the string value.
+// It returns false if the value cannot be set this way.
+func (v *Value) setText(newValue *Value) (bool, error) {
+	if !v.value.CanAddr() || !newValue.IsString() || !reflect.PointerTo(v.value.Type()).Implements(textUnmarshalerType) {
+		return false, nil
+	}
+	s, err := newValue.StringValue()
+	if err != nil {
+		return false, err
+	}
+	if err := v.value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+		return false, fmt.Errorf("error unmarshaling %s from text: %w", v.value.Type(), err)
+	}
+	return true, nil
+}
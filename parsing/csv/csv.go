@@ -15,6 +15,8 @@ var _ parsing.Writer = (*csvWriter)(nil)
 func init() {
 	parsing.RegisterReader(CSV, newCSVReader)
 	parsing.RegisterWriter(CSV, newCSVWriter)
+	parsing.RegisterFileExtensions(CSV, ".csv")
+	parsing.RegisterDetector(CSV, detectCSV)
 }
 
 func newCSVWriter(options parsing.WriterOptions) (parsing.Writer, error) {
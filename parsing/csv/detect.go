@@ -0,0 +1,19 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+// detectCSV reports whether the data is a comma separated document with a header row,
+// at least one data row and a consistent number of columns.
+func detectCSV(data []byte) parsing.Confidence {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	records, err := r.ReadAll()
+	if err != nil || len(records) < 2 || len(records[0]) < 2 {
+		return parsing.ConfidenceNone
+	}
+	return parsing.ConfidenceLow
+}
@@ -0,0 +1,92 @@
+package parsing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var detectors = map[Format]DetectFn{}
+
+// ErrFormatNotDetected is returned when the format of some data could not be detected.
+var ErrFormatNotDetected = errors.New("could not detect input format")
+
+// ErrAmbiguousFormat is returned when data is equally likely to be in more than one format.
+type ErrAmbiguousFormat struct {
+	Formats []Format
+}
+
+// Error returns the error message.
+func (e ErrAmbiguousFormat) Error() string {
+	formats := make([]string, len(e.Formats))
+	for i, f := range e.Formats {
+		formats[i] = f.String()
+	}
+	return fmt.Sprintf("could not detect input format: input could be any of %s. specify the format explicitly", strings.Join(formats, ", "))
+}
+
+// Confidence describes how likely it is that some data is in a given format.
+type Confidence int
+
+const (
+	// ConfidenceNone means the data is not in the format.
+	ConfidenceNone Confidence = iota
+	// ConfidenceLow means the data can be read by the format, but has no distinguishing features.
+	ConfidenceLow
+	// ConfidenceMedium means the data can be read by the format and contains features typical of it.
+	ConfidenceMedium
+	// ConfidenceHigh means the data is almost certainly in the format.
+	ConfidenceHigh
+)
+
+// DetectFn reports how confident it is that the given data is in a format.
+type DetectFn func(data []byte) Confidence
+
+// RegisterDetector registers a new detector for the format.
+func RegisterDetector(format Format, fn DetectFn) {
+	detectors[format] = fn
+}
+
+// DetectFormat detects the format of the given data.
+// If filename has a registered file extension, the associated format is returned without inspecting the data.
+// Otherwise, the format with the highest confidence from the registered detectors is returned.
+// If more than one format shares the highest confidence an ErrAmbiguousFormat is returned.
+func DetectFormat(data []byte, filename string) (Format, error) {
+	if filename != "" {
+		if f, ok := FormatFromFilename(filename); ok {
+			return f, nil
+		}
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", ErrFormatNotDetected
+	}
+
+	var best []Format
+	bestConfidence := ConfidenceNone
+	for format, fn := range detectors {
+		confidence := fn(data)
+		switch {
+		case confidence == ConfidenceNone || confidence < bestConfidence:
+			continue
+		case confidence > bestConfidence:
+			best = []Format{format}
+			bestConfidence = confidence
+		default:
+			best = append(best, format)
+		}
+	}
+
+	switch len(best) {
+	case 0:
+		return "", ErrFormatNotDetected
+	case 1:
+		return best[0], nil
+	default:
+		slices.Sort(best)
+		return "", ErrAmbiguousFormat{Formats: best}
+	}
+}
@@ -0,0 +1,87 @@
+package parsing_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/parsing"
+	"github.com/tomwright/dasel/v3/parsing/csv"
+	"github.com/tomwright/dasel/v3/parsing/hcl"
+	"github.com/tomwright/dasel/v3/parsing/ini"
+	"github.com/tomwright/dasel/v3/parsing/json"
+	"github.com/tomwright/dasel/v3/parsing/kdl"
+	"github.com/tomwright/dasel/v3/parsing/toml"
+	"github.com/tomwright/dasel/v3/parsing/xml"
+	"github.com/tomwright/dasel/v3/parsing/yaml"
+)
+
+func TestDetectFormat(t *testing.T) {
+	type testCase struct {
+		data     string
+		filename string
+		exp      parsing.Format
+	}
+
+	run := func(tc testCase) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := parsing.DetectFormat([]byte(tc.data), tc.filename)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.exp {
+				t.Errorf("expected format %q, got %q", tc.exp, got)
+			}
+		}
+	}
+
+	t.Run("extension", func(t *testing.T) {
+		t.Run("yaml", run(testCase{data: `{"a": 1}`, filename: "data.yaml", exp: yaml.YAML}))
+		t.Run("yml", run(testCase{filename: "data.yml", exp: yaml.YAML}))
+		t.Run("upper case", run(testCase{filename: "DATA.JSON", exp: json.JSON}))
+		t.Run("tf", run(testCase{filename: "main.tf", exp: hcl.HCL}))
+		t.Run("unknown falls back to content", run(testCase{data: `{"a": 1}`, filename: "data.txt", exp: json.JSON}))
+	})
+
+	t.Run("content", func(t *testing.T) {
+		t.Run("json object", run(testCase{data: `{"a": 1}`, exp: json.JSON}))
+		t.Run("json array", run(testCase{data: `[1, 2, 3]`, exp: json.JSON}))
+		t.Run("ndjson", run(testCase{data: "{\"a\": 1}\n{\"a\": 2}\n", exp: json.JSON}))
+		t.Run("xml", run(testCase{data: "<?xml version=\"1.0\"?>\n<a><b>1</b></a>", exp: xml.XML}))
+		t.Run("yaml mapping", run(testCase{data: "name: Tom\nage: 30\n", exp: yaml.YAML}))
+		t.Run("yaml list", run(testCase{data: "- a\n- b\n", exp: yaml.YAML}))
+		t.Run("yaml multi document", run(testCase{data: "---\na: 1\n---\na: 2\n", exp: yaml.YAML}))
+		t.Run("toml", run(testCase{data: "title = \"x\"\n\n[owner]\nname = \"Tom\"\ndob = 1979-05-27T07:32:00Z\n", exp: toml.TOML}))
+		t.Run("toml array of tables", run(testCase{data: "[[products]]\nname = \"Hammer\"\n", exp: toml.TOML}))
+		t.Run("hcl", run(testCase{data: "resource \"aws_instance\" \"web\" {\n  ami = \"abc\"\n}\n", exp: hcl.HCL}))
+		t.Run("ini", run(testCase{data: "; comment\n[section]\nname = John Smith\n", exp: ini.INI}))
+		t.Run("csv", run(testCase{data: "name,age\nTom,30\nJim,40\n", exp: csv.CSV}))
+		t.Run("kdl", run(testCase{data: "package {\n  name \"dasel\"\n  version \"3\"\n}\n", exp: kdl.KDL}))
+	})
+
+	t.Run("not detected", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"empty":      "",
+			"whitespace": " \n\t ",
+			"scalar":     "hello",
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := parsing.DetectFormat([]byte(data), "")
+				if !errors.Is(err, parsing.ErrFormatNotDetected) {
+					t.Errorf("expected ErrFormatNotDetected, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		_, err := parsing.DetectFormat([]byte("a = b\nc = d\n"), "")
+		var ambiguous parsing.ErrAmbiguousFormat
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("expected ErrAmbiguousFormat, got %v", err)
+		}
+		if !slices.Equal(ambiguous.Formats, []parsing.Format{hcl.HCL, ini.INI}) {
+			t.Errorf("unexpected ambiguous formats: %v", ambiguous.Formats)
+		}
+	})
+}
@@ -2,8 +2,12 @@ package parsing
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 )
 
+var extensions = map[string]Format{}
+
 // Format represents a file format.
 type Format string
 
@@ -51,3 +55,21 @@ func RegisteredWriters() []Format {
 	}
 	return formats
 }
+
+// RegisterFileExtensions registers the given file extensions against the format.
+// Extensions are matched case-insensitively and should include the leading dot, e.g. ".json".
+func RegisterFileExtensions(format Format, exts ...string) {
+	for _, ext := range exts {
+		extensions[strings.ToLower(ext)] = format
+	}
+}
+
+// FormatFromFilename returns the format registered against the extension of the given filename.
+func FormatFromFilename(filename string) (Format, bool) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == "" {
+		return "", false
+	}
+	f, ok := extensions[ext]
+	return f, ok
+}
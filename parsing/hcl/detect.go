@@ -0,0 +1,32 @@
+package hcl
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+var (
+	hclAttributeRegex = regexp.MustCompile(`(?m)^[ \t]*[A-Za-z_][\w-]*[ \t]*=`)
+	hclBlockRegex     = regexp.MustCompile(`(?m)^[ \t]*[A-Za-z_][\w-]*([ \t]+("[^"\n]*"|[A-Za-z_][\w-]*))*[ \t]*\{`)
+)
+
+// detectHCL reports whether the data is a valid HCL document.
+// Documents containing blocks are more likely to be HCL than those containing only attributes.
+func detectHCL(data []byte) parsing.Confidence {
+	confidence := parsing.ConfidenceNone
+	switch {
+	case hclBlockRegex.Match(data):
+		confidence = parsing.ConfidenceMedium
+	case hclAttributeRegex.Match(data):
+		confidence = parsing.ConfidenceLow
+	default:
+		return parsing.ConfidenceNone
+	}
+	if _, diags := hclsyntax.ParseConfig(data, "input", hcl.InitialPos); diags.HasErrors() {
+		return parsing.ConfidenceNone
+	}
+	return confidence
+}
@@ -15,4 +15,6 @@ var _ parsing.Writer = (*hclWriter)(nil)
 func init() {
 	parsing.RegisterReader(HCL, newHCLReader)
 	parsing.RegisterWriter(HCL, newHCLWriter)
+	parsing.RegisterFileExtensions(HCL, ".hcl", ".tf", ".tfvars")
+	parsing.RegisterDetector(HCL, detectHCL)
 }
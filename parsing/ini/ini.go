@@ -12,4 +12,6 @@ const (
 func init() {
 	parsing.RegisterReader(INI, newINIReader)
 	parsing.RegisterWriter(INI, newINIWriter)
+	parsing.RegisterFileExtensions(INI, ".ini")
+	parsing.RegisterDetector(INI, detectINI)
 }
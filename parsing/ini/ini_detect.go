@@ -0,0 +1,21 @@
+package ini
+
+import (
+	"regexp"
+
+	"github.com/tomwright/dasel/v3/parsing"
+	"gopkg.in/ini.v1"
+)
+
+var iniKeyValueOrSectionRegex = regexp.MustCompile(`(?m)^[ \t]*([^\s=;#\[][^=\n]*=|\[[^\[\]\n]+\][ \t]*$)`)
+
+// detectINI reports whether the data is a valid INI document containing at least one key or section.
+func detectINI(data []byte) parsing.Confidence {
+	if !iniKeyValueOrSectionRegex.Match(data) {
+		return parsing.ConfidenceNone
+	}
+	if _, err := ini.LoadSources(ini.LoadOptions{}, data); err != nil {
+		return parsing.ConfidenceNone
+	}
+	return parsing.ConfidenceLow
+}
@@ -18,4 +18,6 @@ const (
 func init() {
 	parsing.RegisterReader(JSON, newJSONReader)
 	parsing.RegisterWriter(JSON, newJSONWriter)
+	parsing.RegisterFileExtensions(JSON, ".json", ".ndjson", ".jsonl")
+	parsing.RegisterDetector(JSON, detectJSON)
 }
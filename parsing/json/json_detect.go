@@ -0,0 +1,28 @@
+package json
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	json "github.com/goccy/go-json"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+// detectJSON reports whether the data is a JSON document, or a stream of JSON documents.
+func detectJSON(data []byte) parsing.Confidence {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || (data[0] != '{' && data[0] != '[') {
+		return parsing.ConfidenceNone
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var v json.RawMessage
+		if err := decoder.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return parsing.ConfidenceHigh
+			}
+			return parsing.ConfidenceNone
+		}
+	}
+}
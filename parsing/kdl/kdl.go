@@ -8,4 +8,6 @@ const KDL parsing.Format = "kdl"
 func init() {
 	parsing.RegisterReader(KDL, newKDLReader)
 	parsing.RegisterWriter(KDL, newKDLWriter)
+	parsing.RegisterFileExtensions(KDL, ".kdl")
+	parsing.RegisterDetector(KDL, detectKDL)
 }
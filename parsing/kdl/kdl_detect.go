@@ -0,0 +1,23 @@
+package kdl
+
+import (
+	"regexp"
+
+	"github.com/tomwright/dasel/v3/parsing"
+	"github.com/tomwright/dasel/v3/parsing/kdl/internal"
+)
+
+// kdlNodeWithEntriesRegex matches a node with at least one argument or property.
+// A bare identifier is valid KDL, but says nothing about the format.
+var kdlNodeWithEntriesRegex = regexp.MustCompile(`(?m)^[ \t]*([A-Za-z_][\w.-]*|"[^"\n]*")[ \t]+[^\s=]`)
+
+// detectKDL reports whether the data is a valid KDL document.
+func detectKDL(data []byte) parsing.Confidence {
+	if !kdlNodeWithEntriesRegex.Match(data) {
+		return parsing.ConfidenceNone
+	}
+	if _, err := internal.Parse(string(data)); err != nil {
+		return parsing.ConfidenceNone
+	}
+	return parsing.ConfidenceLow
+}
@@ -12,4 +12,6 @@ const TOML parsing.Format = "toml"
 func init() {
 	parsing.RegisterReader(TOML, newTOMLReader)
 	parsing.RegisterWriter(TOML, newTOMLWriter)
+	parsing.RegisterFileExtensions(TOML, ".toml")
+	parsing.RegisterDetector(TOML, detectTOML)
 }
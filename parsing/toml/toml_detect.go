@@ -0,0 +1,22 @@
+package toml
+
+import (
+	"regexp"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+var tomlKeyValueOrTableRegex = regexp.MustCompile(`(?m)^[ \t]*(("[^"\n]*"|'[^'\n]*'|[\w.-]+)[ \t]*=|\[\[?[ \t]*("[^"\n]*"|'[^'\n]*'|[\w.-]+))`)
+
+// detectTOML reports whether the data is a valid TOML document containing at least one key or table.
+func detectTOML(data []byte) parsing.Confidence {
+	if !tomlKeyValueOrTableRegex.Match(data) {
+		return parsing.ConfidenceNone
+	}
+	var v map[string]any
+	if err := toml.Unmarshal(data, &v); err != nil {
+		return parsing.ConfidenceNone
+	}
+	return parsing.ConfidenceMedium
+}
@@ -0,0 +1,36 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+
+	"github.com/tomwright/dasel/v3/parsing"
+)
+
+// detectXML reports whether the data is a well-formed XML document.
+func detectXML(data []byte) parsing.Confidence {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '<' {
+		return parsing.ConfidenceNone
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	hasElement := false
+	for {
+		t, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return parsing.ConfidenceNone
+		}
+		if _, ok := t.(xml.StartElement); ok {
+			hasElement = true
+		}
+	}
+	if !hasElement {
+		return parsing.ConfidenceNone
+	}
+	return parsing.ConfidenceHigh
+}
@@ -19,6 +19,8 @@ var _ parsing.Writer = (*xmlWriter)(nil)
 func init() {
 	parsing.RegisterReader(XML, newXMLReader)
 	parsing.RegisterWriter(XML, newXMLWriter)
+	parsing.RegisterFileExtensions(XML, ".xml")
+	parsing.RegisterDetector(XML, detectXML)
 }
 
 type xmlAttr struct {
@@ -12,6 +12,8 @@ const YAML parsing.Format = "yaml"
 func init() {
 	parsing.RegisterReader(YAML, newYAMLReader)
 	parsing.RegisterWriter(YAML, newYAMLWriter)
+	parsing.RegisterFileExtensions(YAML, ".yaml", ".yml")
+	parsing.RegisterDetector(YAML, detectYAML)
 }
 
 type yamlValue struct {
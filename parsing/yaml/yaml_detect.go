@@ -0,0 +1,42 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+
+	"github.com/tomwright/dasel/v3/parsing"
+	"go.yaml.in/yaml/v4"
+)
+
+var (
+	yamlDocumentStartRegex = regexp.MustCompile(`^(---|%YAML)`)
+	yamlMappingOrListRegex = regexp.MustCompile(`(?m)^[ \t]*(-[ \t]|-$|("[^"]*"|'[^']*'|[^\s#=:\[\]{}"'][^=:\n]*?):([ \t]|$))`)
+)
+
+// detectYAML reports whether the data is a YAML document containing a mapping or sequence.
+// Plain scalars are valid YAML but are not considered a match.
+func detectYAML(data []byte) parsing.Confidence {
+	data = bytes.TrimSpace(data)
+	confidence := parsing.ConfidenceNone
+	switch {
+	case yamlDocumentStartRegex.Match(data):
+		confidence = parsing.ConfidenceMedium
+	case yamlMappingOrListRegex.Match(data):
+		confidence = parsing.ConfidenceLow
+	default:
+		return parsing.ConfidenceNone
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return confidence
+			}
+			return parsing.ConfidenceNone
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/tomwright/dasel/v3/selector/lexer"
+
 type Program struct {
 	Statements []Statement
 }
@@ -14,6 +16,27 @@ type Expr interface {
 	expr()
 }
 
+// SpanOf returns the location of the given expression within the selector it was parsed from.
+// The span is only known for some expressions, and only when they were produced by the parser.
+func SpanOf(e Expr) (lexer.Span, bool) {
+	var span lexer.Span
+	switch e := e.(type) {
+	case CallExpr:
+		span = e.Span
+	case PropertyExpr:
+		span = e.Span
+	case VariableExpr:
+		span = e.Span
+	case BranchExpr:
+		span = e.Span
+	case BinaryExpr:
+		span = e.Operator.Span()
+	case UnaryExpr:
+		span = e.Operator.Span()
+	}
+	return span, span.Len > 0
+}
+
 func IsType[T Expr](e Expr) bool {
 	_, ok := AsType[T](e)
 	return ok
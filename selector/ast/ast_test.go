@@ -9,6 +9,7 @@ func TestExpr_expr(t *testing.T) {
 	NumberFloatExpr{}.expr()
 	NumberIntExpr{}.expr()
 	StringExpr{}.expr()
+	InterpolatedStringExpr{}.expr()
 	BoolExpr{}.expr()
 	BinaryExpr{}.expr()
 	UnaryExpr{}.expr()
@@ -39,6 +40,10 @@ func TestExpr_expr(t *testing.T) {
 	AssignExpr{}.expr()
 	NullExpr{}.expr()
 	RegexExpr{}.expr()
+	DeleteExpr{}.expr()
+	WalkExpr{}.expr()
+	FuncDefExpr{}.expr()
+	ImportExpr{}.expr()
 }
 
 func TestChainExprs(t *testing.T) {
@@ -20,6 +20,8 @@ func (UnaryExpr) expr() {}
 type CallExpr struct {
 	Function string
 	Args     Expressions
+	// Span is the location of the function name within the selector.
+	Span lexer.Span
 }
 
 func (CallExpr) expr() {}
@@ -70,6 +72,8 @@ type PropertyExpr struct {
 	// If it resolves to a number, we expect to be reading from an array.
 	// If it resolves to a string, we expect to be reading from a map.
 	Property Expr
+	// Span is the location of the property within the selector.
+	Span lexer.Span
 }
 
 func (PropertyExpr) expr() {}
@@ -163,6 +167,8 @@ func (CountExpr) expr() {}
 
 type VariableExpr struct {
 	Name string
+	// Span is the location of the variable within the selector.
+	Span lexer.Span
 }
 
 func (VariableExpr) expr() {}
@@ -183,6 +189,8 @@ func (ConditionalExpr) expr() {}
 
 type BranchExpr struct {
 	Exprs []Expr
+	// Span is the location of the branch keyword within the selector.
+	Span lexer.Span
 }
 
 func (BranchExpr) expr() {}
@@ -199,3 +207,31 @@ type AssignExpr struct {
 }
 
 func (AssignExpr) expr() {}
+
+type DeleteExpr struct {
+	Exprs Expressions
+}
+
+func (DeleteExpr) expr() {}
+
+// WalkExpr replaces every node in the value bottom-up with the result of Expr.
+type WalkExpr struct {
+	Expr Expr
+}
+
+func (WalkExpr) expr() {}
+
+type FuncDefExpr struct {
+	Name   string
+	Params []string
+	Body   Expr
+}
+
+func (FuncDefExpr) expr() {}
+
+type ImportExpr struct {
+	Path  string
+	Alias string
+}
+
+func (ImportExpr) expr() {}
@@ -20,6 +20,14 @@ type StringExpr struct {
 
 func (StringExpr) expr() {}
 
+// InterpolatedStringExpr is a string literal containing ${...} expressions.
+// Literal sections are represented as StringExpr.
+type InterpolatedStringExpr struct {
+	Parts Expressions
+}
+
+func (InterpolatedStringExpr) expr() {}
+
 type BoolExpr struct {
 	Value bool
 }
@@ -0,0 +1,510 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// precedence is how tightly an expression binds when printed next to others.
+// It mirrors the binding powers used by the parser.
+type precedence int
+
+const (
+	// precLowest is used by expressions that can only be written as a statement or within parentheses.
+	precLowest precedence = iota
+	precAssignment
+	precTernary
+	precLogical
+	precEarlyLogical
+	precRelational
+	precAdditive
+	precMultiplicative
+	precUnary
+	precPrimary
+)
+
+var operatorPrecedence = map[lexer.TokenKind]precedence{
+	lexer.Star:               precMultiplicative,
+	lexer.Slash:              precMultiplicative,
+	lexer.Percent:            precMultiplicative,
+	lexer.Plus:               precAdditive,
+	lexer.Dash:               precAdditive,
+	lexer.Equal:              precRelational,
+	lexer.NotEqual:           precRelational,
+	lexer.GreaterThan:        precRelational,
+	lexer.GreaterThanOrEqual: precRelational,
+	lexer.LessThan:           precRelational,
+	lexer.LessThanOrEqual:    precRelational,
+	lexer.DoubleQuestionMark: precEarlyLogical,
+	lexer.And:                precLogical,
+	lexer.Or:                 precLogical,
+	lexer.Like:               precLogical,
+	lexer.NotLike:            precLogical,
+	lexer.Equals:             precAssignment,
+}
+
+// Format returns the canonical selector text for the given expression.
+// Parsing the result gives an expression that behaves the same as the one given.
+// Redundant parentheses and chains are removed, strings are double quoted and
+// statements are separated by "; ".
+func Format(e Expr) string {
+	if e == nil {
+		return ""
+	}
+	return formatStatements(e)
+}
+
+// formatStatements formats the expression as a sequence of statements, as found at the top level of a selector.
+func formatStatements(e Expr) string {
+	if chain, ok := e.(ChainedExpr); ok {
+		return formatChain(flattenChain(chain), true)
+	}
+	return formatExpr(e)
+}
+
+// formatExpr formats the expression where any number of chained expressions may be given,
+// such as within function arguments or parentheses.
+func formatExpr(e Expr) string {
+	switch e := e.(type) {
+	case nil:
+		return ""
+	case NumberIntExpr:
+		return strconv.FormatInt(e.Value, 10)
+	case NumberFloatExpr:
+		s := strconv.FormatFloat(e.Value, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	case StringExpr:
+		return quote(e.Value)
+	case InterpolatedStringExpr:
+		return formatInterpolatedString(e)
+	case BoolExpr:
+		return strconv.FormatBool(e.Value)
+	case NullExpr:
+		return "null"
+	case RegexExpr:
+		if e.Regex == nil {
+			return "r//"
+		}
+		return "r/" + e.Regex.String() + "/"
+	case BinaryExpr:
+		p := precedenceOf(e)
+		return formatOperand(e.Left, p) + " " + e.Operator.Value + " " + formatOperand(e.Right, p+1)
+	case UnaryExpr:
+		return e.Operator.Value + formatOperand(e.Right, precUnary)
+	case AssignExpr:
+		return formatExpr(e.Variable) + " = " + formatOperand(e.Value, precAssignment+1)
+	case CallExpr:
+		return e.Function + "(" + formatList(e.Args) + ")"
+	case ChainedExpr:
+		return formatChain(flattenChain(e), false)
+	case SpreadExpr:
+		return "..."
+	case PropertyExpr:
+		if s, ok := e.Property.(StringExpr); ok && lexer.IsSymbol(s.Value) {
+			return s.Value
+		}
+		return formatChain([]Expr{e}, false)
+	case RangeExpr, IndexExpr:
+		return formatChain([]Expr{e}, false)
+	case VariableExpr:
+		return "$" + e.Name
+	case ArrayExpr:
+		return "[" + formatList(e.Exprs) + "]"
+	case ObjectExpr:
+		return formatObject(e)
+	case MapExpr:
+		return "map(" + formatExpr(e.Expr) + ")"
+	case EachExpr:
+		return "each(" + formatExpr(e.Expr) + ")"
+	case FilterExpr:
+		return "filter(" + formatExpr(e.Expr) + ")"
+	case SearchExpr:
+		return "search(" + formatExpr(e.Expr) + ")"
+	case RecursiveDescentExpr:
+		if e.IsWildcard || e.Expr == nil {
+			return "..*"
+		}
+		return ".." + strings.TrimPrefix(formatChain([]Expr{e.Expr}, false), "$this")
+	case SortByExpr:
+		if e.Descending {
+			return "sortBy(" + formatExpr(e.Expr) + ", desc)"
+		}
+		return "sortBy(" + formatExpr(e.Expr) + ")"
+	case GroupByExpr:
+		return "groupBy(" + formatExpr(e.Expr) + ")"
+	case ReduceExpr:
+		return "reduce(" + formatList(Expressions{e.Expr, e.Init, e.Update}) + ")"
+	case MapValuesExpr:
+		return "mapValues(" + formatExpr(e.Expr) + ")"
+	case AnyExpr:
+		return "any(" + formatExpr(e.Expr) + ")"
+	case AllExpr:
+		return "all(" + formatExpr(e.Expr) + ")"
+	case CountExpr:
+		return "count(" + formatExpr(e.Expr) + ")"
+	case GroupExpr:
+		return "(" + formatExpr(e.Expr) + ")"
+	case ConditionalExpr:
+		return formatConditional(e)
+	case BranchExpr:
+		return "branch(" + formatList(e.Exprs) + ")"
+	case DeleteExpr:
+		return "del(" + formatList(e.Exprs) + ")"
+	case WalkExpr:
+		return "walk(" + formatExpr(e.Expr) + ")"
+	case FuncDefExpr:
+		return "def " + e.Name + "(" + strings.Join(e.Params, ", ") + ") = " + formatExpr(e.Body)
+	case ImportExpr:
+		return "import " + quote(e.Path) + " as " + e.Alias
+	}
+	return ""
+}
+
+// formatSingle formats an expression where only a single expression is parsed,
+// such as object keys and values, or the branches of a ternary.
+func formatSingle(e Expr) string {
+	return formatOperand(e, precAssignment)
+}
+
+// formatOperand formats the expression, wrapping it in parentheses if it binds less tightly than min.
+func formatOperand(e Expr, min precedence) string {
+	s := formatExpr(e)
+	if precedenceOf(e) < min {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// formatList formats the expressions as a comma separated list.
+func formatList(exprs Expressions) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = formatExpr(e)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// precedenceOf returns how tightly the given expression binds.
+func precedenceOf(e Expr) precedence {
+	switch e := e.(type) {
+	case BinaryExpr:
+		if p, ok := operatorPrecedence[e.Operator.Kind]; ok {
+			return p
+		}
+		return precLowest
+	case AssignExpr:
+		return precAssignment
+	case ConditionalExpr:
+		if isTernary(e) {
+			return precTernary
+		}
+	case UnaryExpr:
+		return precUnary
+	case FuncDefExpr, ImportExpr:
+		return precLowest
+	case ChainedExpr:
+		if isJuxtaposed(flattenChain(e)) {
+			return precLowest
+		}
+	}
+	return precPrimary
+}
+
+// flattenChain returns the expressions of the chain, with any nested chains expanded.
+func flattenChain(chain ChainedExpr) []Expr {
+	var res []Expr
+	for _, e := range chain.Exprs {
+		if nested, ok := e.(ChainedExpr); ok {
+			res = append(res, flattenChain(nested)...)
+			continue
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// chainLink is how an expression is joined to the expression before it within a chain.
+type chainLink int
+
+const (
+	// linkNone is used by the first expression of a chain.
+	linkNone chainLink = iota
+	// linkStatement separates statements, e.g. $x = 1; $x
+	linkStatement
+	// linkDot separates properties, e.g. foo.bar
+	linkDot
+	// linkDirect is used by expressions that directly follow another, e.g. foo[0], foo... or foo..bar
+	linkDirect
+	// linkSpace juxtaposes expressions where a dot can't be used, e.g. len()... $this[0]
+	linkSpace
+)
+
+// chainState describes what may follow the expressions written so far within a chain.
+type chainState int
+
+const (
+	// stateStart is used at the start of a chain or statement.
+	stateStart chainState = iota
+	// stateDot is used when only a dot may follow.
+	stateDot
+	// stateFollowing is used when a dot or square brackets may follow.
+	stateFollowing
+	// stateSpread is used after a spread that can't be followed by a dot.
+	stateSpread
+)
+
+// chainLinks returns how each of the chained expressions is joined to the one before it.
+// If statements is true, expressions that can't be chained with a dot are separated as statements.
+func chainLinks(exprs []Expr, statements bool) []chainLink {
+	links := make([]chainLink, len(exprs))
+	state := stateStart
+	// primary is true when the previous expression started a new operand, rather than following a dot.
+	primary := true
+	prevStatement := false
+	for i, e := range exprs {
+		statement := statements && precedenceOf(e) < precPrimary
+		start := linkNone
+		if i > 0 && (statement || prevStatement) {
+			start = linkStatement
+			state = stateStart
+		}
+		prevStatement = statement
+		if statement {
+			links[i] = start
+			continue
+		}
+
+		_, bracket := formatBrackets(e)
+		switch {
+		case bracket:
+			links[i] = map[chainState]chainLink{stateStart: start, stateDot: linkDot, stateFollowing: linkDirect, stateSpread: linkSpace}[state]
+			primary = links[i] != linkDot && links[i] != linkDirect
+			state = stateFollowing
+		case IsType[SpreadExpr](e):
+			links[i] = linkDirect
+			switch {
+			case state == stateStart:
+				links[i] = start
+				state = stateDot
+			case state == stateDot && primary:
+				// The spread ends the operand, so a dot can no longer follow.
+				state = stateSpread
+			}
+		case IsType[RecursiveDescentExpr](e):
+			links[i] = map[chainState]chainLink{stateStart: start, stateDot: linkDirect, stateFollowing: linkDirect, stateSpread: linkSpace}[state]
+			primary = true
+			state = stateDot
+		default:
+			links[i] = map[chainState]chainLink{stateStart: start, stateDot: linkDot, stateFollowing: linkDot, stateSpread: linkSpace}[state]
+			primary = links[i] != linkDot
+			state = stateDot
+			if acceptsBrackets(e) {
+				state = stateFollowing
+			}
+		}
+	}
+	return links
+}
+
+// isJuxtaposed returns true if the chain can only be parsed where expressions may be given
+// one after another without a separator, such as within parentheses.
+func isJuxtaposed(exprs []Expr) bool {
+	for i, link := range chainLinks(exprs, false) {
+		if link == linkSpace || (link == linkDirect && IsType[RecursiveDescentExpr](exprs[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatChain formats the chained expressions.
+// If statements is true, expressions that can't be chained with a dot are separated by "; ".
+// Otherwise they are wrapped in parentheses.
+func formatChain(exprs []Expr, statements bool) string {
+	links := chainLinks(exprs, statements)
+	buf := new(strings.Builder)
+	for i, e := range exprs {
+		switch links[i] {
+		case linkStatement:
+			buf.WriteString("; ")
+		case linkDot:
+			buf.WriteString(".")
+		case linkSpace:
+			buf.WriteString(" ")
+		}
+
+		if statements && precedenceOf(e) < precPrimary {
+			buf.WriteString(formatExpr(e))
+			continue
+		}
+		if bracket, ok := formatBrackets(e); ok {
+			if links[i] != linkDirect {
+				buf.WriteString("$this")
+			}
+			buf.WriteString(bracket)
+			continue
+		}
+
+		s := formatOperand(e, precPrimary)
+		if IsType[NumberIntExpr](e) || IsType[NumberFloatExpr](e) {
+			// Avoid the following dot being read as a decimal point.
+			if i < len(exprs)-1 {
+				s = "(" + s + ")"
+			}
+		}
+		buf.WriteString(s)
+	}
+	return buf.String()
+}
+
+// formatBrackets formats expressions that are written in square brackets, e.g. [0] or [1:2].
+func formatBrackets(e Expr) (string, bool) {
+	switch e := e.(type) {
+	case PropertyExpr:
+		if s, ok := e.Property.(StringExpr); ok && lexer.IsSymbol(s.Value) {
+			return "", false
+		}
+		return "[" + formatBracketOperand(e.Property) + "]", true
+	case IndexExpr:
+		return "[" + formatBracketOperand(e.Index) + "]", true
+	case RangeExpr:
+		return "[" + formatBracketOperand(e.Start) + ":" + formatBracketOperand(e.End) + "]", true
+	}
+	return "", false
+}
+
+// formatBracketOperand formats an expression within square brackets.
+// Ternaries are wrapped in parentheses so their colon isn't confused with that of a range.
+func formatBracketOperand(e Expr) string {
+	if e == nil {
+		return ""
+	}
+	return formatOperand(e, precTernary+1)
+}
+
+// acceptsBrackets returns true if square brackets may directly follow the given expression.
+func acceptsBrackets(e Expr) bool {
+	switch e.(type) {
+	case PropertyExpr, VariableExpr, ArrayExpr, ObjectExpr, IndexExpr, RangeExpr,
+		MapExpr, FilterExpr, ReduceExpr, CountExpr, GroupByExpr, MapValuesExpr, AnyExpr, AllExpr:
+		return true
+	}
+	return false
+}
+
+// isTernary returns true if the conditional expression is written as cond ? then : else.
+// Conditionals without an else, or with further conditions, are written using if.
+func isTernary(e ConditionalExpr) bool {
+	if e.Else == nil {
+		return false
+	}
+	_, elseIf := e.Else.(ConditionalExpr)
+	return !elseIf
+}
+
+func formatConditional(e ConditionalExpr) string {
+	if isTernary(e) {
+		then := formatSingle(e.Then)
+		if c, ok := e.Then.(ConditionalExpr); ok && isTernary(c) {
+			then = "(" + then + ")"
+		}
+		return formatOperand(e.Cond, precTernary+1) + " ? " + then + " : " + formatSingle(e.Else)
+	}
+
+	buf := new(strings.Builder)
+	buf.WriteString("if (" + formatExpr(e.Cond) + ") { " + formatExpr(e.Then) + " }")
+	for e.Else != nil {
+		next, ok := e.Else.(ConditionalExpr)
+		if !ok {
+			buf.WriteString(" else { " + formatExpr(e.Else) + " }")
+			break
+		}
+		e = next
+		buf.WriteString(" elseif (" + formatExpr(e.Cond) + ") { " + formatExpr(e.Then) + " }")
+	}
+	return buf.String()
+}
+
+func formatObject(e ObjectExpr) string {
+	pairs := make([]string, len(e.Pairs))
+	for i, pair := range e.Pairs {
+		pairs[i] = formatKeyValue(pair)
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+func formatKeyValue(kv KeyValue) string {
+	if _, ok := kv.Key.(SpreadExpr); ok {
+		return formatOperand(kv.Value, precPrimary) + "..."
+	}
+
+	switch key := kv.Key.(type) {
+	case StringExpr:
+		if prop, ok := kv.Value.(PropertyExpr); ok && prop.Property == kv.Key && lexer.IsSymbol(key.Value) {
+			return key.Value
+		}
+		if lexer.IsSymbol(key.Value) {
+			return key.Value + ": " + formatSingle(kv.Value)
+		}
+		return quote(key.Value) + ": " + formatSingle(kv.Value)
+	case PropertyExpr:
+		// A bare property would be read as the name of the key.
+		return "$this." + formatExpr(key) + ": " + formatSingle(kv.Value)
+	}
+	return formatOperand(kv.Key, precPrimary) + ": " + formatSingle(kv.Value)
+}
+
+func formatInterpolatedString(e InterpolatedStringExpr) string {
+	buf := new(strings.Builder)
+	buf.WriteByte('"')
+	for _, part := range e.Parts {
+		if s, ok := part.(StringExpr); ok {
+			writeEscaped(buf, s.Value)
+			continue
+		}
+		buf.WriteString("${" + formatStatements(part) + "}")
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// quote returns the string as a double quoted string literal.
+func quote(s string) string {
+	buf := new(strings.Builder)
+	buf.WriteByte('"')
+	writeEscaped(buf, s)
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// writeEscaped writes the contents of a double quoted string literal.
+func writeEscaped(buf *strings.Builder, s string) {
+	for i, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '$':
+			// Avoid a literal ${ being read as an interpolation.
+			if strings.HasPrefix(s[i+1:], "{") {
+				buf.WriteString(`\$`)
+			} else {
+				buf.WriteByte('$')
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+}
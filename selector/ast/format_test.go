@@ -0,0 +1,104 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{name: "empty", in: "", exp: ""},
+		{name: "property", in: "foo", exp: "foo"},
+		{name: "chain", in: "foo . bar.baz", exp: "foo.bar.baz"},
+		{name: "this", in: "$this", exp: "$this"},
+		{name: "int", in: "123", exp: "123"},
+		{name: "float", in: "1.50", exp: "1.5"},
+		{name: "float without fraction", in: "2f", exp: "2.0"},
+		{name: "bool", in: "TRUE", exp: "true"},
+		{name: "null", in: "NULL", exp: "null"},
+		{name: "single quoted string", in: `'a "b"'`, exp: `"a \"b\""`},
+		{name: "escaped string", in: `"a\tb\\c"`, exp: `"a\tb\\c"`},
+		{name: "literal interpolation", in: `"a\${b}"`, exp: `"a\${b}"`},
+		{name: "single quoted interpolation", in: `'a${b}'`, exp: `"a\${b}"`},
+		{name: "interpolated string", in: `"a${ b.c }d"`, exp: `"a${b.c}d"`},
+		{name: "binary", in: "1+2*3", exp: "1 + 2 * 3"},
+		{name: "grouped binary", in: "(1 + 2) * 3", exp: "(1 + 2) * 3"},
+		{name: "redundant parentheses", in: "((1 * 2)) + (3)", exp: "1 * 2 + 3"},
+		{name: "right associative group", in: "a - (b - c)", exp: "a - (b - c)"},
+		{name: "left associative group", in: "(a - b) - c", exp: "a - b - c"},
+		{name: "logical", in: "a>1&&b<2||c", exp: "a > 1 && b < 2 || c"},
+		{name: "unary", in: "!a && b", exp: "!a && b"},
+		{name: "unary group", in: "!(a && b)", exp: "!(a && b)"},
+		{name: "negative", in: "-1", exp: "-1"},
+		{name: "coalesce", in: "a ?? b ?? 1", exp: "a ?? b ?? 1"},
+		{name: "like", in: "name =~ r/^a.*$/", exp: "name =~ r/^a.*$/"},
+		{name: "call", in: "len( foo )", exp: "len(foo)"},
+		{name: "call with args", in: "add(1,2 , 3)", exp: "add(1, 2, 3)"},
+		{name: "chained call", in: "foo.toString()", exp: "foo.toString()"},
+		{name: "index", in: "foo[ 0 ]", exp: "foo[0]"},
+		{name: "index after call", in: "foo.toString().$this[0]", exp: "foo.toString().$this[0]"},
+		{name: "quoted property", in: `foo["a b"]`, exp: `foo["a b"]`},
+		{name: "range", in: "foo[1 : 2]", exp: "foo[1:2]"},
+		{name: "open range", in: "foo[:2][1:]", exp: "foo[:2][1:]"},
+		{name: "spread", in: "foo ...", exp: "foo..."},
+		{name: "spread then property", in: "foo....bar", exp: "foo....bar"},
+		{name: "recursive descent", in: "..foo", exp: "..foo"},
+		{name: "recursive descent wildcard", in: "foo..*", exp: "foo..*"},
+		{name: "recursive descent index", in: "..[0]", exp: "..[0]"},
+		{name: "property after spread of call", in: "(toString(foo)...).bar", exp: "toString(foo)... bar"},
+		{name: "recursive descent in binary", in: "(a..b) + 1", exp: "(a..b) + 1"},
+		{name: "recursive descent in args", in: "len(foo..bar)", exp: "len(foo..bar)"},
+		{name: "array", in: "[1,2, 3]", exp: "[1, 2, 3]"},
+		{name: "array of unary", in: "[!true, false]", exp: "[!true, false]"},
+		{name: "object", in: `{a:1, "b c": 2, 'd':3}`, exp: `{a: 1, "b c": 2, d: 3}`},
+		{name: "object shorthand", in: "{name, age}", exp: "{name, age}"},
+		{name: "object spread", in: "{$x..., a: 1}", exp: "{$x..., a: 1}"},
+		{name: "object dynamic key", in: "{$this.name: 1}", exp: "{$this.name: 1}"},
+		{name: "map", in: "map( name )", exp: "map(name)"},
+		{name: "filter", in: "users.filter(age>18).map(name)", exp: "users.filter(age > 18).map(name)"},
+		{name: "sort", in: "sortBy($this , desc)", exp: "sortBy($this, desc)"},
+		{name: "sort ascending", in: "sortBy($this, asc)", exp: "sortBy($this)"},
+		{name: "reduce", in: "reduce(price,0,$acc+$this)", exp: "reduce(price, 0, $acc + $this)"},
+		{name: "ternary", in: "a?b:c", exp: "a ? b : c"},
+		{name: "nested ternary", in: "a ? (b ? c : d) : e", exp: "a ? (b ? c : d) : e"},
+		{name: "ternary else ternary", in: "a ? b : c ? d : e", exp: "if (a) { b } elseif (c) { d } else { e }"},
+		{name: "if", in: "if(a){b}", exp: "if (a) { b }"},
+		{name: "if else", in: "if (a) { b } else { c }", exp: "a ? b : c"},
+		{name: "if elseif", in: "if(a){b}elseif(c){d}else{e}", exp: "if (a) { b } elseif (c) { d } else { e }"},
+		{name: "assignment", in: "$x=1;$x+1", exp: "$x = 1; $x + 1"},
+		{name: "statements", in: "foo;bar", exp: "foo.bar"},
+		{name: "assignment in chain", in: "foo.($x = 1).bar", exp: "foo; $x = 1; bar"},
+		{name: "def", in: "def double(x)=$x*2;double(1)", exp: "def double(x) = $x * 2; double(1)"},
+		{name: "import", in: `import 'lib.dsl' as lib; lib.double(1)`, exp: `import "lib.dsl" as lib; lib.double(1)`},
+		{name: "delete", in: "delete(foo, bar)", exp: "del(foo, bar)"},
+		{name: "branch", in: "branch(1,2)", exp: "branch(1, 2)"},
+		{name: "number in chain", in: "(1).toString()", exp: "(1).toString()"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := selector.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error parsing input: %v", err)
+			}
+			got := ast.Format(expr)
+			if got != tc.exp {
+				t.Fatalf("expected %q, got %q", tc.exp, got)
+			}
+
+			reparsed, err := selector.Parse(got)
+			if err != nil {
+				t.Fatalf("unexpected error parsing formatted selector: %v", err)
+			}
+			if again := ast.Format(reparsed); again != got {
+				t.Errorf("formatting is not stable: %q became %q", got, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package ast
+
+// Inspect traverses the expression depth-first, calling fn for each node.
+// If fn returns false, the children of the node are not visited.
+func Inspect(e Expr, fn func(Expr) bool) {
+	if e == nil || !fn(e) {
+		return
+	}
+	for _, c := range children(e) {
+		Inspect(c.expr, fn)
+	}
+}
@@ -0,0 +1,179 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// child is a named child expression of a node.
+type child struct {
+	name string
+	expr Expr
+}
+
+// Describe returns a single line description of the given node, without its children.
+// E.g. `BinaryExpr ">"` or `CallExpr len`.
+func Describe(e Expr) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", e), "ast.")
+	if attrs := attributes(e); attrs != "" {
+		return name + " " + attrs
+	}
+	return name
+}
+
+// Fprint writes the given expression to w as an indented tree, one node per line.
+func Fprint(w io.Writer, e Expr) error {
+	return fprint(w, e, "", 0)
+}
+
+func fprint(w io.Writer, e Expr, label string, depth int) error {
+	line := "<nil>"
+	if e != nil {
+		line = Describe(e)
+	}
+	if label != "" {
+		line = label + ": " + line
+	}
+	if _, err := fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", depth), line); err != nil {
+		return err
+	}
+	for _, c := range children(e) {
+		if err := fprint(w, c.expr, c.name, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attributes returns the non-expression fields of the node, formatted for display.
+func attributes(e Expr) string {
+	switch e := e.(type) {
+	case NumberIntExpr:
+		return strconv.FormatInt(e.Value, 10)
+	case NumberFloatExpr:
+		return strconv.FormatFloat(e.Value, 'g', -1, 64)
+	case StringExpr:
+		return strconv.Quote(e.Value)
+	case BoolExpr:
+		return strconv.FormatBool(e.Value)
+	case RegexExpr:
+		if e.Regex == nil {
+			return ""
+		}
+		return "r/" + e.Regex.String() + "/"
+	case BinaryExpr:
+		return strconv.Quote(e.Operator.Value)
+	case UnaryExpr:
+		return strconv.Quote(e.Operator.Value)
+	case CallExpr:
+		return e.Function
+	case VariableExpr:
+		return "$" + e.Name
+	case RecursiveDescentExpr:
+		if e.IsWildcard {
+			return "(wildcard)"
+		}
+	case SortByExpr:
+		if e.Descending {
+			return "(descending)"
+		}
+	case AssignExpr:
+		return "$" + e.Variable.Name
+	case FuncDefExpr:
+		return e.Name + "(" + strings.Join(e.Params, ", ") + ")"
+	case ImportExpr:
+		return strconv.Quote(e.Path) + " as " + e.Alias
+	}
+	return ""
+}
+
+// children returns the child expressions of the node.
+func children(e Expr) []child {
+	indexed := func(name string, exprs []Expr) []child {
+		res := make([]child, len(exprs))
+		for i, expr := range exprs {
+			res[i] = child{name: fmt.Sprintf("%s[%d]", name, i), expr: expr}
+		}
+		return res
+	}
+	optional := func(children ...child) []child {
+		res := make([]child, 0, len(children))
+		for _, c := range children {
+			if c.expr != nil {
+				res = append(res, c)
+			}
+		}
+		return res
+	}
+
+	switch e := e.(type) {
+	case InterpolatedStringExpr:
+		return indexed("Parts", e.Parts)
+	case BinaryExpr:
+		return []child{{"Left", e.Left}, {"Right", e.Right}}
+	case UnaryExpr:
+		return []child{{"Right", e.Right}}
+	case CallExpr:
+		return indexed("Args", e.Args)
+	case ChainedExpr:
+		return indexed("Exprs", e.Exprs)
+	case RangeExpr:
+		return optional(child{"Start", e.Start}, child{"End", e.End})
+	case IndexExpr:
+		return []child{{"Index", e.Index}}
+	case ArrayExpr:
+		return indexed("Exprs", e.Exprs)
+	case PropertyExpr:
+		return []child{{"Property", e.Property}}
+	case ObjectExpr:
+		var res []child
+		for i, p := range e.Pairs {
+			res = append(res, optional(
+				child{fmt.Sprintf("Pairs[%d].Key", i), p.Key},
+				child{fmt.Sprintf("Pairs[%d].Value", i), p.Value},
+			)...)
+		}
+		return res
+	case MapExpr:
+		return []child{{"Expr", e.Expr}}
+	case EachExpr:
+		return []child{{"Expr", e.Expr}}
+	case FilterExpr:
+		return []child{{"Expr", e.Expr}}
+	case SearchExpr:
+		return []child{{"Expr", e.Expr}}
+	case RecursiveDescentExpr:
+		return optional(child{"Expr", e.Expr})
+	case SortByExpr:
+		return []child{{"Expr", e.Expr}}
+	case GroupByExpr:
+		return []child{{"Expr", e.Expr}}
+	case ReduceExpr:
+		return []child{{"Init", e.Init}, {"Expr", e.Expr}, {"Update", e.Update}}
+	case MapValuesExpr:
+		return []child{{"Expr", e.Expr}}
+	case AnyExpr:
+		return []child{{"Expr", e.Expr}}
+	case AllExpr:
+		return []child{{"Expr", e.Expr}}
+	case CountExpr:
+		return []child{{"Expr", e.Expr}}
+	case GroupExpr:
+		return []child{{"Expr", e.Expr}}
+	case ConditionalExpr:
+		return optional(child{"Cond", e.Cond}, child{"Then", e.Then}, child{"Else", e.Else})
+	case BranchExpr:
+		return indexed("Exprs", e.Exprs)
+	case AssignExpr:
+		return []child{{"Value", e.Value}}
+	case DeleteExpr:
+		return indexed("Exprs", e.Exprs)
+	case WalkExpr:
+		return []child{{"Expr", e.Expr}}
+	case FuncDefExpr:
+		return []child{{"Body", e.Body}}
+	}
+	return nil
+}
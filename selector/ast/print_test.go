@@ -0,0 +1,53 @@
+package ast_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/ast"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr ast.Expr
+		exp  string
+	}{
+		{expr: ast.StringExpr{Value: "users"}, exp: `StringExpr "users"`},
+		{expr: ast.NumberIntExpr{Value: 18}, exp: `NumberIntExpr 18`},
+		{expr: ast.CallExpr{Function: "len"}, exp: `CallExpr len`},
+		{expr: ast.VariableExpr{Name: "x"}, exp: `VariableExpr $x`},
+		{expr: ast.FuncDefExpr{Name: "f", Params: []string{"a", "b"}}, exp: `FuncDefExpr f(a, b)`},
+		{expr: ast.ChainedExpr{}, exp: `ChainedExpr`},
+	}
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			if got := ast.Describe(test.expr); got != test.exp {
+				t.Errorf("expected %q, got %q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	expr, err := selector.Parse(`users.filter(age > 18)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf := new(bytes.Buffer)
+	if err := ast.Fprint(buf, expr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `ChainedExpr
+  Exprs[0]: PropertyExpr
+    Property: StringExpr "users"
+  Exprs[1]: FilterExpr
+    Expr: BinaryExpr ">"
+      Left: PropertyExpr
+        Property: StringExpr "age"
+      Right: NumberIntExpr 18
+`
+	if got := buf.String(); got != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
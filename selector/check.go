@@ -0,0 +1,346 @@
+package selector
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// unstableFuncs are the functions that may only be called when unstable features are enabled.
+var unstableFuncs = []string{
+	"ignore",
+}
+
+// IsUnstable returns true if the expression may only be executed when unstable features are enabled.
+// Child expressions are not considered.
+func IsUnstable(e ast.Expr) bool {
+	switch e := e.(type) {
+	case ast.BranchExpr:
+		return true
+	case ast.CallExpr:
+		return slices.Contains(unstableFuncs, e.Function)
+	}
+	return false
+}
+
+// FuncChecker reports whether functions exist and accept a given number of arguments.
+// It is implemented by execution.FuncCollection.
+type FuncChecker interface {
+	// CheckCall returns false if there is no function with the given name.
+	// Otherwise it returns an error if the function cannot be called with the given number of arguments.
+	// If args is negative the number of arguments is not known.
+	CheckCall(name string, args int) (bool, error)
+}
+
+// Problem is an issue found by Check.
+type Problem struct {
+	Message string
+	// Location is the part of the selector the problem relates to.
+	Location lexer.Span
+}
+
+// Error returns the problem message.
+func (p Problem) Error() string {
+	return p.Message
+}
+
+// Span returns the location of the problem within the selector, if it is known.
+func (p Problem) Span() (lexer.Span, bool) {
+	return p.Location, p.Location.Len > 0
+}
+
+// CheckOption configures the checks made by Check.
+type CheckOption func(*checkOptions)
+
+type checkOptions struct {
+	vars     []string
+	imports  []string
+	unstable bool
+}
+
+// WithVariables declares variables that are given to the selector when it is executed.
+func WithVariables(names ...string) CheckOption {
+	return func(o *checkOptions) {
+		o.vars = append(o.vars, names...)
+	}
+}
+
+// WithImports declares the aliases of selector files that are imported before the selector is executed.
+// Functions and variables within these namespaces are not checked.
+func WithImports(aliases ...string) CheckOption {
+	return func(o *checkOptions) {
+		o.imports = append(o.imports, aliases...)
+	}
+}
+
+// WithUnstable allows the use of potentially unstable features.
+func WithUnstable() CheckOption {
+	return func(o *checkOptions) {
+		o.unstable = true
+	}
+}
+
+// Check finds problems in the given expression without executing it.
+// It reports calls to unknown functions, calls with the wrong number of arguments,
+// variables that are used without being defined and unstable features used without WithUnstable.
+// If funcs is nil, only calls to functions defined within the selector are checked.
+// Problems are returned in the order they are found.
+func Check(expr ast.Expr, funcs FuncChecker, opts ...CheckOption) []Problem {
+	o := &checkOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	root := &checkScope{}
+	for _, name := range []string{"this", "path"} {
+		root.setVar(name)
+	}
+	for _, name := range o.vars {
+		root.setVar(name)
+	}
+	for _, alias := range o.imports {
+		root.setImport(alias)
+	}
+
+	c := &checker{funcs: funcs, unstable: o.unstable}
+	c.check(expr, root)
+	// Function bodies are checked last, since they may use anything defined
+	// within their scope before they are called.
+	for len(c.defs) > 0 {
+		def := c.defs[0]
+		c.defs = c.defs[1:]
+		c.check(def.body, def.scope)
+	}
+	return c.problems
+}
+
+// checkScope contains the names visible to an expression.
+// It mirrors the scopes created when a selector is executed.
+type checkScope struct {
+	parent  *checkScope
+	vars    map[string]bool
+	defs    map[string]int
+	imports map[string]bool
+}
+
+// child returns a new scope within s containing the given variables.
+func (s *checkScope) child(vars ...string) *checkScope {
+	c := &checkScope{parent: s}
+	for _, name := range vars {
+		c.setVar(name)
+	}
+	return c
+}
+
+func (s *checkScope) setVar(name string) {
+	if s.vars == nil {
+		s.vars = map[string]bool{}
+	}
+	s.vars[name] = true
+}
+
+func (s *checkScope) setDef(name string, params int) {
+	if s.defs == nil {
+		s.defs = map[string]int{}
+	}
+	s.defs[name] = params
+}
+
+func (s *checkScope) setImport(alias string) {
+	if s.imports == nil {
+		s.imports = map[string]bool{}
+	}
+	s.imports[alias] = true
+}
+
+func (s *checkScope) hasVar(name string) bool {
+	for ; s != nil; s = s.parent {
+		if s.vars[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupDef returns the number of parameters of the function definition visible from s.
+func (s *checkScope) lookupDef(name string) (int, bool) {
+	for ; s != nil; s = s.parent {
+		if params, ok := s.defs[name]; ok {
+			return params, true
+		}
+	}
+	return 0, false
+}
+
+// isImported returns true if the name is within the namespace of an imported file, e.g. k8s::name.
+func (s *checkScope) isImported(name string) bool {
+	alias, _, ok := strings.Cut(name, "::")
+	if !ok {
+		return false
+	}
+	for ; s != nil; s = s.parent {
+		if s.imports[alias] {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingDef is a function body that is yet to be checked.
+type pendingDef struct {
+	body  ast.Expr
+	scope *checkScope
+}
+
+type checker struct {
+	funcs    FuncChecker
+	unstable bool
+	problems []Problem
+	defs     []pendingDef
+}
+
+func (c *checker) report(e ast.Expr, format string, args ...any) {
+	span, _ := ast.SpanOf(e)
+	c.problems = append(c.problems, Problem{Message: fmt.Sprintf(format, args...), Location: span})
+}
+
+func (c *checker) checkAll(exprs []ast.Expr, s *checkScope) {
+	for _, e := range exprs {
+		c.check(e, s)
+	}
+}
+
+func (c *checker) check(expr ast.Expr, s *checkScope) {
+	if !c.unstable && IsUnstable(expr) {
+		c.report(expr, "%s is unstable and requires unstable features to be enabled", describeUnstable(expr))
+	}
+
+	switch e := expr.(type) {
+	case ast.InterpolatedStringExpr:
+		c.checkAll(e.Parts, s)
+	case ast.BinaryExpr:
+		if v, ok := e.Left.(ast.VariableExpr); ok && e.Operator.IsKind(lexer.Equals) {
+			// Assignments define the variable before the value is evaluated.
+			if !s.hasVar(v.Name) {
+				s.setVar(v.Name)
+			}
+		}
+		c.check(e.Left, s)
+		c.check(e.Right, s)
+	case ast.UnaryExpr:
+		c.check(e.Right, s)
+	case ast.CallExpr:
+		c.checkAll(e.Args, s)
+		c.checkCall(e, s)
+	case ast.ChainedExpr:
+		c.checkAll(e.Exprs, s)
+	case ast.RangeExpr:
+		c.check(e.Start, s)
+		c.check(e.End, s)
+	case ast.IndexExpr:
+		c.check(e.Index, s)
+	case ast.ArrayExpr:
+		c.checkAll(e.Exprs, s)
+	case ast.PropertyExpr:
+		c.check(e.Property, s)
+	case ast.ObjectExpr:
+		for _, pair := range e.Pairs {
+			c.check(pair.Key, s)
+			c.check(pair.Value, s)
+		}
+	case ast.MapExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.EachExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.FilterExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.SearchExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.RecursiveDescentExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.SortByExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.GroupByExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.ReduceExpr:
+		c.check(e.Init, s)
+		item := s.child("key")
+		c.check(e.Expr, item)
+		c.check(e.Update, item.child("acc"))
+	case ast.MapValuesExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.AnyExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.AllExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.CountExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.GroupExpr:
+		c.check(e.Expr, s)
+	case ast.ConditionalExpr:
+		c.check(e.Cond, s)
+		c.check(e.Then, s)
+		c.check(e.Else, s)
+	case ast.BranchExpr:
+		c.checkAll(e.Exprs, s)
+	case ast.AssignExpr:
+		c.check(e.Value, s)
+		s.setVar(e.Variable.Name)
+	case ast.DeleteExpr:
+		c.checkAll(e.Exprs, s)
+	case ast.WalkExpr:
+		c.check(e.Expr, s.child("key"))
+	case ast.FuncDefExpr:
+		s.setDef(e.Name, len(e.Params))
+		c.defs = append(c.defs, pendingDef{body: e.Body, scope: s.child(e.Params...)})
+	case ast.ImportExpr:
+		s.setImport(e.Alias)
+	case ast.VariableExpr:
+		if !s.hasVar(e.Name) && !s.isImported(e.Name) {
+			c.report(e, "undefined variable: $%s", e.Name)
+		}
+	}
+}
+
+func (c *checker) checkCall(e ast.CallExpr, s *checkScope) {
+	args := argCount(e.Args)
+	if params, ok := s.lookupDef(e.Function); ok {
+		if args >= 0 && args != params {
+			c.report(e, "func %q expects exactly %d arguments, got %d", e.Function, params, args)
+		}
+		return
+	}
+	if s.isImported(e.Function) || c.funcs == nil {
+		return
+	}
+
+	exists, err := c.funcs.CheckCall(e.Function, args)
+	switch {
+	case !exists:
+		c.report(e, "unknown function: %q", e.Function)
+	case err != nil:
+		c.report(e, "%s", err.Error())
+	}
+}
+
+// argCount returns the number of arguments given, or -1 if it depends on spread values.
+func argCount(args ast.Expressions) int {
+	for _, arg := range args {
+		if ast.IsType[ast.SpreadExpr](ast.Last(arg)) {
+			return -1
+		}
+	}
+	return len(args)
+}
+
+func describeUnstable(e ast.Expr) string {
+	if call, ok := e.(ast.CallExpr); ok {
+		return fmt.Sprintf("func %q", call.Function)
+	}
+	return strings.TrimSuffix(strings.ToLower(strings.TrimPrefix(fmt.Sprintf("%T", e), "ast.")), "expr")
+}
@@ -0,0 +1,108 @@
+package selector_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tomwright/dasel/v3/execution"
+	"github.com/tomwright/dasel/v3/selector"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+func TestCheck(t *testing.T) {
+	type testCase struct {
+		in   string
+		opts []selector.CheckOption
+		exp  []selector.Problem
+	}
+
+	run := func(tc testCase) func(t *testing.T) {
+		return func(t *testing.T) {
+			expr, err := selector.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error parsing selector: %v", err)
+			}
+			got := selector.Check(expr, execution.DefaultFuncCollection, tc.opts...)
+			if !cmp.Equal(tc.exp, got) {
+				t.Errorf("unexpected problems:\n%s", cmp.Diff(tc.exp, got))
+			}
+		}
+	}
+
+	t.Run("valid", run(testCase{
+		in: `$x = 1; users.filter(age > $x && $key < 10).map(name.toUpper())`,
+	}))
+	t.Run("unknown function", run(testCase{
+		in: `name.toUpperCase()`,
+		exp: []selector.Problem{
+			{Message: `unknown function: "toUpperCase"`, Location: lexer.Span{Pos: 5, Len: 11}},
+		},
+	}))
+	t.Run("wrong argument count", run(testCase{
+		in: `len(1, 2)`,
+		exp: []selector.Problem{
+			{Message: `func "len" expects exactly 1 arguments, got 2`, Location: lexer.Span{Pos: 0, Len: 3}},
+		},
+	}))
+	t.Run("spread arguments are not counted", run(testCase{
+		in: `$x = [1, 2]; add($x...)`,
+	}))
+	t.Run("undefined variable", run(testCase{
+		in: `foo + $bar`,
+		exp: []selector.Problem{
+			{Message: `undefined variable: $bar`, Location: lexer.Span{Pos: 6, Len: 4}},
+		},
+	}))
+	t.Run("declared variable", run(testCase{
+		in:   `foo + $bar`,
+		opts: []selector.CheckOption{selector.WithVariables("bar")},
+	}))
+	t.Run("variables do not leak out of map", run(testCase{
+		in: `map($x = $key); $x`,
+		exp: []selector.Problem{
+			{Message: `undefined variable: $x`, Location: lexer.Span{Pos: 16, Len: 2}},
+		},
+	}))
+	t.Run("key is only defined within map", run(testCase{
+		in: `$key`,
+		exp: []selector.Problem{
+			{Message: `undefined variable: $key`, Location: lexer.Span{Pos: 0, Len: 4}},
+		},
+	}))
+	t.Run("reduce acc", run(testCase{
+		in: `reduce($this, 0, $acc + $this)`,
+	}))
+	t.Run("user defined functions", run(testCase{
+		in: `def a(x) = b($x) + $y; def b(x) = $x; $y = 1; a(1)`,
+	}))
+	t.Run("user defined function argument count", run(testCase{
+		in: `def double(x) = $x * 2; double(1, 2)`,
+		exp: []selector.Problem{
+			{Message: `func "double" expects exactly 1 arguments, got 2`, Location: lexer.Span{Pos: 24, Len: 6}},
+		},
+	}))
+	t.Run("undefined variable in function body", run(testCase{
+		in: `def f(x) = $y; f(1)`,
+		exp: []selector.Problem{
+			{Message: `undefined variable: $y`, Location: lexer.Span{Pos: 11, Len: 2}},
+		},
+	}))
+	t.Run("imports are not checked", run(testCase{
+		in: `import "lib.dsl" as lib; lib::f($lib::x)`,
+	}))
+	t.Run("imports given as options are not checked", run(testCase{
+		in:   `k8s::name(metadata.name)`,
+		opts: []selector.CheckOption{selector.WithImports("k8s")},
+	}))
+	t.Run("unstable", run(testCase{
+		in: `branch(1, ignore())`,
+		exp: []selector.Problem{
+			{Message: `branch is unstable and requires unstable features to be enabled`, Location: lexer.Span{Pos: 0, Len: 6}},
+			{Message: `func "ignore" is unstable and requires unstable features to be enabled`, Location: lexer.Span{Pos: 10, Len: 6}},
+		},
+	}))
+	t.Run("unstable enabled", run(testCase{
+		in:   `branch(1, ignore())`,
+		opts: []selector.CheckOption{selector.WithUnstable()},
+	}))
+}
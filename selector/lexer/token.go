@@ -73,6 +73,9 @@ const (
 	QuestionMark
 	DoubleQuestionMark
 	Semicolon
+	Def
+	Import
+	InterpolatedString
 )
 
 type Tokens []Token
@@ -116,6 +119,24 @@ func (t Token) IsKind(kind ...TokenKind) bool {
 	return slices.Contains(kind, t.Kind)
 }
 
+// Span returns the span of the selector the token was read from.
+func (t Token) Span() Span {
+	return Span{Pos: t.Pos, Len: t.Len}
+}
+
+// Span is a range of bytes within a selector.
+type Span struct {
+	Pos int
+	Len int
+}
+
+// SpanError is implemented by errors that relate to a span of the selector.
+type SpanError interface {
+	error
+	// Span returns the span of the selector the error relates to, if it is known.
+	Span() (Span, bool)
+}
+
 type UnexpectedTokenError struct {
 	Pos   int
 	Token rune
@@ -125,6 +146,11 @@ func (e *UnexpectedTokenError) Error() string {
 	return fmt.Sprintf("failed to tokenize: unexpected token: %s at position %d.", string(e.Token), e.Pos)
 }
 
+// Span returns the span of the unexpected token.
+func (e *UnexpectedTokenError) Span() (Span, bool) {
+	return Span{Pos: e.Pos, Len: len(string(e.Token))}, true
+}
+
 type UnexpectedEOFError struct {
 	Pos int
 }
@@ -132,3 +158,8 @@ type UnexpectedEOFError struct {
 func (e *UnexpectedEOFError) Error() string {
 	return fmt.Sprintf("failed to tokenize: unexpected EOF at position %d.", e.Pos)
 }
+
+// Span returns the position at which the selector ended.
+func (e *UnexpectedEOFError) Span() (Span, bool) {
+	return Span{Pos: e.Pos}, true
+}
@@ -110,12 +110,7 @@ func (p *Tokenizer) parseCurRune() (Token, error) {
 		return NewToken(Percent, "%", p.i, 1), nil
 	case '$':
 		if p.peekRuneMatches(p.i+1, unicode.IsLetter) || p.peekRuneEqual(p.i+1, '_') {
-			pos := p.i + 1
-			for pos < p.srcLen && (unicode.IsLetter(rune(p.src[pos])) ||
-				unicode.IsDigit(rune(p.src[pos])) ||
-				p.src[pos] == '_') {
-				pos++
-			}
+			pos := p.scanIdentifier(p.i + 1)
 			return NewToken(Variable, p.src[p.i+1:pos], p.i, pos-p.i), nil
 		}
 		return NewToken(Dollar, "$", p.i, 1), nil
@@ -183,77 +178,18 @@ func (p *Tokenizer) parseCurRune() (Token, error) {
 		}
 		return NewToken(QuestionMark, "?", p.i, 1), nil
 	case '"', '\'':
-		pos := p.i
-		buf := make([]rune, 0)
-
-		escapeCharacters := map[rune]bool{
-			// The closing quote character can be escaped.
-			rune(p.src[p.i]): true,
-			'\\':             true,
-			't':              true,
-			'r':              true,
-			'n':              true,
-		}
-
-		pos++
-		foundCloseRune := false
-		for pos < p.srcLen {
-			if p.src[pos] == p.src[p.i] {
-				foundCloseRune = true
-				break
-			}
-			if p.src[pos] == '\\' {
-				// Handle escape characters.
-				pos++
-
-				if pos >= p.srcLen {
-					return Token{}, &UnexpectedEOFError{Pos: pos}
-				}
-
-				if p.peekRuneMatches(pos, func(r rune) bool {
-					_, ok := escapeCharacters[r]
-					return ok
-				}) {
-					switch p.src[pos] {
-					case '\\':
-						buf = append(buf, '\\')
-					case 't':
-						buf = append(buf, '\t')
-					case 'r':
-						buf = append(buf, '\r')
-					case 'n':
-						buf = append(buf, '\n')
-					default:
-						// This must be the closing quote character.
-						buf = append(buf, rune(p.src[pos]))
-					}
-					pos++
-				} else {
-					buf = append(buf, rune(p.src[pos]))
-					pos++
-				}
-
-				continue
-			}
-			buf = append(buf, rune(p.src[pos]))
-			pos++
+		parts, end, err := p.scanString(p.i)
+		if err != nil {
+			return Token{}, err
 		}
-		if !foundCloseRune {
-			// We didn't find a closing quote.
-			if pos < p.srcLen {
-				// This shouldn't be possible.
-				return Token{}, &UnexpectedTokenError{
-					Pos:   p.i,
-					Token: rune(p.src[pos]),
-				}
-			}
-			// This can happen if the selector ends before the closing quote.
-			return Token{}, &UnexpectedEOFError{
-				Pos: pos,
+		var buf strings.Builder
+		for _, part := range parts {
+			if part.Expr {
+				return NewToken(InterpolatedString, p.src[p.i:end+1], p.i, end+1-p.i), nil
 			}
+			buf.WriteString(part.Value)
 		}
-		res := NewToken(String, string(buf), p.i, pos+1-p.i)
-		return res, nil
+		return NewToken(String, buf.String(), p.i, end+1-p.i), nil
 	default:
 		pos := p.i
 
@@ -300,6 +236,26 @@ func (p *Tokenizer) parseCurRune() (Token, error) {
 		if t := matchStr(pos, "false", true, Bool); t != nil {
 			return *t, nil
 		}
+		// import is only a keyword when followed by the path to import, so that it can still be used as a property.
+		if t := matchStr(pos, "import", false, Import); t != nil {
+			next := pos + 6
+			for next < p.srcLen && unicode.IsSpace(rune(p.src[next])) {
+				next++
+			}
+			if next > pos+6 && next < p.srcLen && (p.src[next] == '"' || p.src[next] == '\'') {
+				return *t, nil
+			}
+		}
+		// def is only a keyword when followed by a function name, so that it can still be used as a property.
+		if t := matchStr(pos, "def", false, Def); t != nil && pos+3 < p.srcLen && unicode.IsSpace(rune(p.src[pos+3])) {
+			next := pos + 3
+			for next < p.srcLen && unicode.IsSpace(rune(p.src[next])) {
+				next++
+			}
+			if next < p.srcLen && (unicode.IsLetter(rune(p.src[next])) || p.src[next] == '_') {
+				return *t, nil
+			}
+		}
 		if t := matchStr(pos, "elseif", false, ElseIf); t != nil {
 			return *t, nil
 		}
@@ -375,11 +331,7 @@ func (p *Tokenizer) parseCurRune() (Token, error) {
 		}
 
 		if unicode.IsLetter(rune(p.src[pos])) || p.src[pos] == '_' {
-			for pos < p.srcLen && (unicode.IsLetter(rune(p.src[pos])) ||
-				unicode.IsDigit(rune(p.src[pos])) ||
-				p.src[pos] == '_') {
-				pos++
-			}
+			pos = p.scanIdentifier(pos)
 			return NewToken(Symbol, p.src[p.i:pos], p.i, pos-p.i), nil
 		}
 
@@ -390,6 +342,150 @@ func (p *Tokenizer) parseCurRune() (Token, error) {
 	}
 }
 
+// StringPart is a section of a string literal.
+// Expression parts contain the source of an embedded ${...} expression.
+type StringPart struct {
+	Value string
+	Expr  bool
+	// Pos is the position of the part within the string literal source.
+	Pos int
+}
+
+// InterpolatedStringParts splits the source of a quoted string literal, as found in the
+// value of an InterpolatedString token, into its literal and expression parts.
+func InterpolatedStringParts(src string) ([]StringPart, error) {
+	parts, _, err := NewTokenizer(src).scanString(0)
+	return parts, err
+}
+
+// scanString scans the quoted string starting at start, returning its parts and the position of the closing quote.
+// Only double quoted strings may contain ${...} expressions.
+func (p *Tokenizer) scanString(start int) ([]StringPart, int, error) {
+	quote := p.src[start]
+	escapeCharacters := map[rune]bool{
+		// The closing quote character can be escaped.
+		rune(quote): true,
+		'\\':        true,
+		't':         true,
+		'r':         true,
+		'n':         true,
+		// Allows a literal ${ to be written as \${.
+		'$': true,
+	}
+
+	var parts []StringPart
+	buf := make([]rune, 0)
+	bufPos := start + 1
+	flush := func(pos int) {
+		if len(buf) > 0 {
+			parts = append(parts, StringPart{Value: string(buf), Pos: bufPos - start})
+		}
+		buf = make([]rune, 0)
+		bufPos = pos
+	}
+
+	pos := start + 1
+	for pos < p.srcLen {
+		if p.src[pos] == quote {
+			flush(pos)
+			return parts, pos, nil
+		}
+		if p.src[pos] == '\\' {
+			// Handle escape characters.
+			pos++
+
+			if pos >= p.srcLen {
+				return nil, pos, &UnexpectedEOFError{Pos: pos}
+			}
+
+			if p.peekRuneMatches(pos, func(r rune) bool {
+				_, ok := escapeCharacters[r]
+				return ok
+			}) {
+				switch p.src[pos] {
+				case '\\':
+					buf = append(buf, '\\')
+				case 't':
+					buf = append(buf, '\t')
+				case 'r':
+					buf = append(buf, '\r')
+				case 'n':
+					buf = append(buf, '\n')
+				default:
+					// This must be the closing quote character or $.
+					buf = append(buf, rune(p.src[pos]))
+				}
+				pos++
+			} else {
+				buf = append(buf, rune(p.src[pos]))
+				pos++
+			}
+
+			continue
+		}
+		if quote == '"' && p.src[pos] == '$' && p.peekRuneEqual(pos+1, '{') {
+			flush(pos)
+			exprStart := pos + 2
+			exprEnd, err := p.scanInterpolation(exprStart)
+			if err != nil {
+				return nil, exprEnd, err
+			}
+			parts = append(parts, StringPart{Value: p.src[exprStart:exprEnd], Expr: true, Pos: exprStart - start})
+			pos = exprEnd + 1
+			bufPos = pos
+			continue
+		}
+		buf = append(buf, rune(p.src[pos]))
+		pos++
+	}
+	// This can happen if the selector ends before the closing quote.
+	return nil, pos, &UnexpectedEOFError{
+		Pos: pos,
+	}
+}
+
+// scanInterpolation returns the position of the } that closes the ${ expression starting at pos.
+// Braces and strings within the expression are skipped over.
+func (p *Tokenizer) scanInterpolation(pos int) (int, error) {
+	depth := 1
+	for pos < p.srcLen {
+		switch p.src[pos] {
+		case '"', '\'':
+			_, end, err := p.scanString(pos)
+			if err != nil {
+				return end, err
+			}
+			pos = end
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return pos, nil
+			}
+		}
+		pos++
+	}
+	return pos, &UnexpectedEOFError{Pos: pos}
+}
+
+// scanIdentifier returns the position of the end of the identifier starting at pos.
+// Identifiers may be namespaced with ::, e.g. k8s::labels.
+func (p *Tokenizer) scanIdentifier(pos int) int {
+	for pos < p.srcLen {
+		switch {
+		case unicode.IsLetter(rune(p.src[pos])) || unicode.IsDigit(rune(p.src[pos])) || p.src[pos] == '_':
+			pos++
+		case p.src[pos] == ':' && p.peekRuneEqual(pos+1, ':') &&
+			(p.peekRuneMatches(pos+2, unicode.IsLetter) || p.peekRuneEqual(pos+2, '_')):
+			pos += 2
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
 func (p *Tokenizer) Next() (Token, error) {
 	if p.i >= len(p.src) {
 		return NewToken(EOF, "", p.i, 0), nil
@@ -402,3 +498,10 @@ func (p *Tokenizer) Next() (Token, error) {
 	p.i += t.Len
 	return t, nil
 }
+
+// IsSymbol returns true if the given string is a single symbol token,
+// i.e. it can be written as an unquoted property name in a selector.
+func IsSymbol(s string) bool {
+	tokens, err := NewTokenizer(s).Tokenize()
+	return err == nil && len(tokens) == 1 && tokens[0].IsKind(Symbol) && tokens[0].Value == s
+}
@@ -94,6 +94,16 @@ func TestTokenizer_Parse(t *testing.T) {
 		},
 	}.run)
 
+	t.Run("interpolated strings", testCase{
+		in: `"host-${name}.example.com" "a${ {'b': '}'}.b }c" "\${x}" '${x}'`,
+		out: []lexer.TokenKind{
+			lexer.InterpolatedString,
+			lexer.InterpolatedString,
+			lexer.String,
+			lexer.String,
+		},
+	}.run)
+
 	t.Run("if", testCase{
 		in: `if elseif else`,
 		out: []lexer.TokenKind{
@@ -103,6 +113,29 @@ func TestTokenizer_Parse(t *testing.T) {
 		},
 	}.run)
 
+	t.Run("def", testCase{
+		in: `def add(a, b) = $a + $b; def == 1; default`,
+		out: []lexer.TokenKind{
+			lexer.Def,
+			lexer.Symbol,
+			lexer.OpenParen,
+			lexer.Symbol,
+			lexer.Comma,
+			lexer.Symbol,
+			lexer.CloseParen,
+			lexer.Equals,
+			lexer.Variable,
+			lexer.Plus,
+			lexer.Variable,
+			lexer.Semicolon,
+			lexer.Symbol,
+			lexer.Equal,
+			lexer.Number,
+			lexer.Semicolon,
+			lexer.Symbol,
+		},
+	}.run)
+
 	t.Run("regex", testCase{
 		in: `r/asd/ r/hello there/`,
 		out: []lexer.TokenKind{
@@ -181,6 +214,10 @@ func TestTokenizer_Parse(t *testing.T) {
 			in:    `'\`,
 			match: matchUnexpectedEOFError(2),
 		}.run)
+		t.Run("unterminated interpolation", errTestCase{
+			in:    `"a${b"`,
+			match: matchUnexpectedEOFError(6),
+		}.run)
 		t.Run("unterminated regex", errTestCase{
 			in:    `r/unterminated`,
 			match: matchUnexpectedEOFError(14),
@@ -218,3 +255,40 @@ func TestTokenizer_Parse(t *testing.T) {
 		}.run)
 	})
 }
+
+func TestInterpolatedStringParts(t *testing.T) {
+	parts, err := lexer.InterpolatedStringParts(`"a-${name}\${x}${ {"b": "}"}.b }"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := []lexer.StringPart{
+		{Value: "a-", Pos: 1},
+		{Value: "name", Expr: true, Pos: 5},
+		{Value: "${x}", Pos: 10},
+		{Value: ` {"b": "}"}.b `, Expr: true, Pos: 17},
+	}
+	if len(parts) != len(exp) {
+		t.Fatalf("expected %d parts, got %d: %+v", len(exp), len(parts), parts)
+	}
+	for i := range exp {
+		if parts[i] != exp[i] {
+			t.Errorf("part %d: expected %+v, got %+v", i, exp[i], parts[i])
+		}
+	}
+}
+
+func TestIsSymbol(t *testing.T) {
+	for in, exp := range map[string]bool{
+		"name":     true,
+		"a_b":      true,
+		"a b":      false,
+		"a.b":      false,
+		"":         false,
+		"1":        false,
+		`"quoted"`: false,
+	} {
+		if got := lexer.IsSymbol(in); got != exp {
+			t.Errorf("IsSymbol(%q): expected %v, got %v", in, exp, got)
+		}
+	}
+}
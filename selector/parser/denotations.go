@@ -47,10 +47,11 @@ const (
 )
 
 var tokenBindingPowers = map[lexer.TokenKind]bindingPower{
-	lexer.String: bpLiteral,
-	lexer.Number: bpLiteral,
-	lexer.Bool:   bpLiteral,
-	lexer.Null:   bpLiteral,
+	lexer.String:             bpLiteral,
+	lexer.InterpolatedString: bpLiteral,
+	lexer.Number:             bpLiteral,
+	lexer.Bool:               bpLiteral,
+	lexer.Null:               bpLiteral,
 
 	lexer.Variable:    bpProperty,
 	lexer.Dot:         bpProperty,
@@ -15,6 +15,16 @@ func (e *PositionalError) Error() string {
 	return fmt.Sprintf("%v. Position %d.", e.Err, e.Position)
 }
 
+// Unwrap returns the underlying error.
+func (e *PositionalError) Unwrap() error {
+	return e.Err
+}
+
+// Span returns the position of the error.
+func (e *PositionalError) Span() (lexer.Span, bool) {
+	return lexer.Span{Pos: e.Position}, true
+}
+
 type UnexpectedTokenError struct {
 	Token lexer.Token
 }
@@ -22,3 +32,8 @@ type UnexpectedTokenError struct {
 func (e *UnexpectedTokenError) Error() string {
 	return fmt.Sprintf("failed to parse: unexpected token %v %q at position %d.", e.Token.Kind, e.Token.Value, e.Token.Pos)
 }
+
+// Span returns the span of the unexpected token.
+func (e *UnexpectedTokenError) Span() (lexer.Span, bool) {
+	return e.Token.Span(), true
+}
@@ -9,6 +9,7 @@ func parseBranch(p *Parser) (ast.Expr, error) {
 	if err := p.expect(lexer.Branch); err != nil {
 		return nil, err
 	}
+	span := p.current().Span()
 
 	p.advance()
 	if err := p.expect(lexer.OpenParen); err != nil {
@@ -29,5 +30,6 @@ func parseBranch(p *Parser) (ast.Expr, error) {
 
 	return ast.BranchExpr{
 		Exprs: expressions,
+		Span:  span,
 	}, nil
 }
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// parseDef parses a function definition, e.g.
+// def slug(x) = $x.toLower().replace(" ", "-")
+func parseDef(p *Parser) (ast.Expr, error) {
+	if err := p.expect(lexer.Def); err != nil {
+		return nil, err
+	}
+	p.advance()
+
+	if err := p.expect(lexer.Symbol); err != nil {
+		return nil, err
+	}
+	name := p.current().Value
+	p.advance()
+
+	if err := p.expect(lexer.OpenParen); err != nil {
+		return nil, err
+	}
+	p.advance()
+
+	params := make([]string, 0)
+	for !p.current().IsKind(lexer.CloseParen) {
+		if len(params) > 0 {
+			if err := p.expect(lexer.Comma); err != nil {
+				return nil, err
+			}
+			p.advance()
+		}
+		if err := p.expect(lexer.Symbol, lexer.Variable); err != nil {
+			return nil, err
+		}
+		params = append(params, p.current().Value)
+		p.advance()
+	}
+	p.advance()
+
+	if err := p.expect(lexer.Equals); err != nil {
+		return nil, err
+	}
+	p.advance()
+
+	body, err := p.parseExpressions(
+		[]lexer.TokenKind{lexer.Semicolon, lexer.EOF},
+		nil,
+		true,
+		bpDefault,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, &UnexpectedTokenError{Token: p.current()}
+	}
+
+	return ast.FuncDefExpr{
+		Name:   name,
+		Params: params,
+		Body:   body,
+	}, nil
+}
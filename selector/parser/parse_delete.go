@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// deleteFuncNames are the names that can be used to call delete.
+var deleteFuncNames = []string{"delete", "del"}
+
+func parseDelete(p *Parser) (ast.Expr, error) {
+	if err := p.expect(lexer.Symbol); err != nil {
+		return nil, err
+	}
+	if err := p.expectN(1, lexer.OpenParen); err != nil {
+		return nil, err
+	}
+
+	p.advanceN(2)
+	args, err := parseArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	return ast.DeleteExpr{
+		Exprs: args,
+	}, nil
+}
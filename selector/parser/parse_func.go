@@ -23,6 +23,7 @@ func parseFunc(p *Parser) (ast.Expr, error) {
 	return ast.CallExpr{
 		Function: token.Value,
 		Args:     args,
+		Span:     token.Span(),
 	}, nil
 }
 
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// parseImport parses an import statement, e.g.
+// import "lib/k8s.dsl" as k8s
+func parseImport(p *Parser) (ast.Expr, error) {
+	if err := p.expect(lexer.Import); err != nil {
+		return nil, err
+	}
+	p.advance()
+
+	if err := p.expect(lexer.String); err != nil {
+		return nil, err
+	}
+	path := p.current().Value
+	p.advance()
+
+	if err := p.expect(lexer.Symbol); err != nil {
+		return nil, err
+	}
+	if p.current().Value != "as" {
+		return nil, &UnexpectedTokenError{Token: p.current()}
+	}
+	p.advance()
+
+	if err := p.expect(lexer.Symbol); err != nil {
+		return nil, err
+	}
+	alias := p.current()
+	if strings.Contains(alias.Value, "::") {
+		return nil, &UnexpectedTokenError{Token: alias}
+	}
+	p.advance()
+
+	return ast.ImportExpr{
+		Path:  path,
+		Alias: alias.Value,
+	}, nil
+}
@@ -18,6 +18,41 @@ func parseStringLiteral(p *Parser) (ast.Expr, error) {
 	}, nil
 }
 
+func parseInterpolatedStringLiteral(p *Parser) (ast.Expr, error) {
+	token := p.current()
+	p.advance()
+
+	parts, err := lexer.InterpolatedStringParts(token.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ast.InterpolatedStringExpr{}
+	for _, part := range parts {
+		if !part.Expr {
+			res.Parts = append(res.Parts, ast.StringExpr{Value: part.Value})
+			continue
+		}
+		tokens, err := lexer.NewTokenizer(part.Value).Tokenize()
+		if err != nil {
+			return nil, err
+		}
+		// Report positions relative to the whole selector.
+		for i := range tokens {
+			tokens[i].Pos += token.Pos + part.Pos
+		}
+		expr, err := NewParser(tokens).Parse()
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			return nil, fmt.Errorf("empty interpolation in string at position %d", token.Pos+part.Pos)
+		}
+		res.Parts = append(res.Parts, expr)
+	}
+	return res, nil
+}
+
 func parseBoolLiteral(p *Parser) (ast.Expr, error) {
 	token := p.current()
 	p.advance()
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"slices"
+
 	"github.com/tomwright/dasel/v3/selector/ast"
 	"github.com/tomwright/dasel/v3/selector/lexer"
 )
@@ -33,10 +35,12 @@ func parseFollowingSymbol(p *Parser, prev ast.Expr) (ast.Expr, error) {
 				continue
 			}
 
+			open := p.current()
 			e, err := parseIndexSquareBrackets(p, false)
 			if err != nil {
 				return nil, err
 			}
+			end := p.tokens[p.i-1]
 			switch ex := e.(type) {
 			case ast.RangeExpr:
 				res = append(res, ex)
@@ -45,6 +49,7 @@ func parseFollowingSymbol(p *Parser, prev ast.Expr) (ast.Expr, error) {
 				// with maps + arrays.
 				res = append(res, ast.PropertyExpr{
 					Property: ex.Index,
+					Span:     lexer.Span{Pos: open.Pos, Len: end.Pos + end.Len - open.Pos},
 				})
 			}
 
@@ -71,11 +76,18 @@ func parseSymbol(p *Parser, withFollowing bool, allowFunc bool) (ast.Expr, error
 
 	// Handle functions
 	if next.IsKind(lexer.OpenParen) && allowFunc {
+		if slices.Contains(deleteFuncNames, token.Value) {
+			return parseDelete(p)
+		}
+		if token.Value == "walk" {
+			return parseWalk(p)
+		}
 		return parseFunc(p)
 	}
 
 	prop := ast.PropertyExpr{
 		Property: ast.StringExpr{Value: token.Value},
+		Span:     token.Span(),
 	}
 
 	p.advance()
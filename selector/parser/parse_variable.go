@@ -9,6 +9,7 @@ func parseVariable(p *Parser) (ast.Expr, error) {
 
 	prop := ast.VariableExpr{
 		Name: token.Value,
+		Span: token.Span(),
 	}
 
 	p.advance()
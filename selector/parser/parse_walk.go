@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/tomwright/dasel/v3/selector/ast"
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+func parseWalk(p *Parser) (ast.Expr, error) {
+	if err := p.expect(lexer.Symbol); err != nil {
+		return nil, err
+	}
+	if err := p.expectN(1, lexer.OpenParen); err != nil {
+		return nil, err
+	}
+
+	p.advanceN(2)
+	args, err := parseArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("walk expects exactly 1 argument, got %d", len(args))
+	}
+	return ast.WalkExpr{
+		Expr: args[0],
+	}, nil
+}
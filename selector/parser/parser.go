@@ -86,20 +86,45 @@ func (p *Parser) Parse() (ast.Expr, error) {
 
 func (p *Parser) parseExpression(bp bindingPower) (left ast.Expr, err error) {
 	if p.hasToken() && slices.Contains(rightDenotationTokens, p.current().Kind) {
-		unary := ast.UnaryExpr{
-			Operator: p.current(),
-			Right:    nil,
+		left, err = p.parseUnary()
+	} else {
+		left, err = p.parsePrimary()
+	}
+	if err != nil {
+		return
+	}
+
+	// Handle binding powers
+	for p.hasToken() && slices.Contains(leftDenotationTokens, p.current().Kind) && getTokenBindingPower(p.current().Kind) > bp {
+		if p.current().IsKind(lexer.QuestionMark) {
+			left, err = parseTernary(p, left)
+		} else {
+			left, err = parseBinary(p, left)
 		}
-		p.advance()
-		expr, err := p.parseExpression(getTokenBindingPower(unary.Operator.Kind))
 		if err != nil {
-			return nil, err
+			return
 		}
-		p.advance()
-		unary.Right = expr
-		left = unary
 	}
 
+	return
+}
+
+// parseUnary parses a unary expression, e.g. !foo
+func (p *Parser) parseUnary() (ast.Expr, error) {
+	operator := p.current()
+	p.advance()
+	expr, err := p.parseExpression(getTokenBindingPower(operator.Kind))
+	if err != nil {
+		return nil, err
+	}
+	return ast.UnaryExpr{
+		Operator: operator,
+		Right:    expr,
+	}, nil
+}
+
+// parsePrimary parses a single operand along with any properties chained onto it, e.g. foo.bar[0]
+func (p *Parser) parsePrimary() (left ast.Expr, err error) {
 	if !p.hasToken() {
 		return
 	}
@@ -107,6 +132,8 @@ func (p *Parser) parseExpression(bp bindingPower) (left ast.Expr, err error) {
 	switch p.current().Kind {
 	case lexer.String:
 		left, err = parseStringLiteral(p)
+	case lexer.InterpolatedString:
+		left, err = parseInterpolatedStringLiteral(p)
 	case lexer.Number:
 		left, err = parseNumberLiteral(p)
 	case lexer.Dash:
@@ -159,6 +186,10 @@ func (p *Parser) parseExpression(bp bindingPower) (left ast.Expr, err error) {
 		left, err = parseAny(p)
 	case lexer.All:
 		left, err = parseAll(p)
+	case lexer.Import:
+		left, err = parseImport(p)
+	case lexer.Def:
+		left, err = parseDef(p)
 	case lexer.Count:
 		left, err = parseCount(p)
 	case lexer.Null:
@@ -201,18 +232,6 @@ func (p *Parser) parseExpression(bp bindingPower) (left ast.Expr, err error) {
 		left = ast.ChainExprs(toChain...)
 	}
 
-	// Handle binding powers
-	for p.hasToken() && slices.Contains(leftDenotationTokens, p.current().Kind) && getTokenBindingPower(p.current().Kind) > bp {
-		if p.current().IsKind(lexer.QuestionMark) {
-			left, err = parseTernary(p, left)
-		} else {
-			left, err = parseBinary(p, left)
-		}
-		if err != nil {
-			return
-		}
-	}
-
 	return
 }
 
@@ -228,7 +247,16 @@ func (p *Parser) current() lexer.Token {
 	if p.hasToken() {
 		return p.tokens[p.i]
 	}
-	return lexer.Token{Kind: lexer.EOF}
+	return p.eof()
+}
+
+// eof returns an EOF token positioned at the end of the last token.
+func (p *Parser) eof() lexer.Token {
+	if len(p.tokens) == 0 {
+		return lexer.Token{Kind: lexer.EOF}
+	}
+	last := p.tokens[len(p.tokens)-1]
+	return lexer.Token{Kind: lexer.EOF, Pos: last.Pos + last.Len}
 }
 
 func (p *Parser) advance() lexer.Token {
@@ -246,7 +274,7 @@ func (p *Parser) peek() lexer.Token {
 
 func (p *Parser) peekN(n int) lexer.Token {
 	if p.i+n >= len(p.tokens) {
-		return lexer.Token{Kind: lexer.EOF}
+		return p.eof()
 	}
 	return p.tokens[p.i+n]
 }
@@ -1,9 +1,11 @@
 package parser_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tomwright/dasel/v3/selector/ast"
 	"github.com/tomwright/dasel/v3/selector/lexer"
 	"github.com/tomwright/dasel/v3/selector/parser"
@@ -23,8 +25,10 @@ func (tc happyTestCase) run(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !cmp.Equal(tc.expected, got) {
-		t.Errorf("unexpected result: %s", cmp.Diff(tc.expected, got))
+	// Spans are covered separately, so the expected trees don't need to include them.
+	ignoreSpans := cmpopts.IgnoreTypes(lexer.Span{})
+	if !cmp.Equal(tc.expected, got, ignoreSpans) {
+		t.Errorf("unexpected result: %s", cmp.Diff(tc.expected, got, ignoreSpans))
 	}
 }
 
@@ -391,6 +395,27 @@ func TestParser_Parse_HappyPath(t *testing.T) {
 				Right:    ast.ChainExprs(ast.PropertyExpr{Property: ast.StringExpr{Value: "foo"}}, ast.PropertyExpr{Property: ast.StringExpr{Value: "b"}}),
 			},
 		}.run)
+		t.Run("not binds tighter than and", happyTestCase{
+			input: `!a && b`,
+			expected: ast.BinaryExpr{
+				Left: ast.UnaryExpr{
+					Operator: lexer.Token{Kind: lexer.Exclamation, Value: "!", Pos: 0, Len: 1},
+					Right:    ast.PropertyExpr{Property: ast.StringExpr{Value: "a"}},
+				},
+				Operator: lexer.Token{Kind: lexer.And, Value: "&&", Pos: 3, Len: 2},
+				Right:    ast.PropertyExpr{Property: ast.StringExpr{Value: "b"}},
+			},
+		}.run)
+		t.Run("not within array", happyTestCase{
+			input: `[!true, false]`,
+			expected: ast.ArrayExpr{Exprs: ast.Expressions{
+				ast.UnaryExpr{
+					Operator: lexer.Token{Kind: lexer.Exclamation, Value: "!", Pos: 1, Len: 1},
+					Right:    ast.BoolExpr{Value: true},
+				},
+				ast.BoolExpr{Value: false},
+			}},
+		}.run)
 	})
 
 	t.Run("conditional", func(t *testing.T) {
@@ -823,6 +848,118 @@ func TestParser_Parse_HappyPath(t *testing.T) {
 		}.run)
 	})
 
+	t.Run("delete", func(t *testing.T) {
+		t.Run("property", happyTestCase{
+			input: "del(foo.bar)",
+			expected: ast.DeleteExpr{
+				Exprs: ast.Expressions{
+					ast.ChainExprs(
+						ast.PropertyExpr{Property: ast.StringExpr{Value: "foo"}},
+						ast.PropertyExpr{Property: ast.StringExpr{Value: "bar"}},
+					),
+				},
+			},
+		}.run)
+		t.Run("multiple paths", happyTestCase{
+			input: "delete(foo, bar[0])",
+			expected: ast.DeleteExpr{
+				Exprs: ast.Expressions{
+					ast.PropertyExpr{Property: ast.StringExpr{Value: "foo"}},
+					ast.ChainExprs(
+						ast.PropertyExpr{Property: ast.StringExpr{Value: "bar"}},
+						ast.PropertyExpr{Property: ast.NumberIntExpr{Value: 0}},
+					),
+				},
+			},
+		}.run)
+		t.Run("property named del", happyTestCase{
+			input:    "del",
+			expected: ast.PropertyExpr{Property: ast.StringExpr{Value: "del"}},
+		}.run)
+	})
+
+	t.Run("walk", func(t *testing.T) {
+		t.Run("expression", happyTestCase{
+			input: `walk($this.trim())`,
+			expected: ast.WalkExpr{
+				Expr: ast.ChainExprs(
+					ast.VariableExpr{Name: "this"},
+					ast.CallExpr{Function: "trim"},
+				),
+			},
+		}.run)
+		t.Run("property named walk", happyTestCase{
+			input:    "walk",
+			expected: ast.PropertyExpr{Property: ast.StringExpr{Value: "walk"}},
+		}.run)
+	})
+
+	t.Run("def", func(t *testing.T) {
+		t.Run("function definition", happyTestCase{
+			input: `def double(x) = $x * 2; double(2)`,
+			expected: ast.ChainExprs(
+				ast.FuncDefExpr{
+					Name:   "double",
+					Params: []string{"x"},
+					Body: ast.BinaryExpr{
+						Left:     ast.VariableExpr{Name: "x"},
+						Operator: lexer.Token{Kind: lexer.Star, Value: "*", Pos: 19, Len: 1},
+						Right:    ast.NumberIntExpr{Value: 2},
+					},
+				},
+				ast.CallExpr{
+					Function: "double",
+					Args:     ast.Expressions{ast.NumberIntExpr{Value: 2}},
+				},
+			),
+		}.run)
+		t.Run("no params", happyTestCase{
+			input: `def name() = name`,
+			expected: ast.FuncDefExpr{
+				Name:   "name",
+				Params: []string{},
+				Body:   ast.PropertyExpr{Property: ast.StringExpr{Value: "name"}},
+			},
+		}.run)
+	})
+
+	t.Run("interpolated string", func(t *testing.T) {
+		t.Run("properties", happyTestCase{
+			input: `"host-${name}.${region}.example.com"`,
+			expected: ast.InterpolatedStringExpr{
+				Parts: ast.Expressions{
+					ast.StringExpr{Value: "host-"},
+					ast.PropertyExpr{Property: ast.StringExpr{Value: "name"}},
+					ast.StringExpr{Value: "."},
+					ast.PropertyExpr{Property: ast.StringExpr{Value: "region"}},
+					ast.StringExpr{Value: ".example.com"},
+				},
+			},
+		}.run)
+		t.Run("method call", happyTestCase{
+			input: `"${a}".toUpper()`,
+			expected: ast.ChainExprs(
+				ast.InterpolatedStringExpr{
+					Parts: ast.Expressions{ast.PropertyExpr{Property: ast.StringExpr{Value: "a"}}},
+				},
+				ast.CallExpr{Function: "toUpper"},
+			),
+		}.run)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		t.Run("namespaced call", happyTestCase{
+			input: `import "lib/k8s.dsl" as k8s; k8s::name($k8s::prefix)`,
+			expected: ast.ChainExprs(
+				ast.ImportExpr{Path: "lib/k8s.dsl", Alias: "k8s"},
+				ast.CallExpr{
+					Function: "k8s::name",
+					Args:     ast.Expressions{ast.VariableExpr{Name: "k8s::prefix"}},
+				},
+			),
+		}.run)
+	})
+
 	t.Run("recursive descent", func(t *testing.T) {
 		t.Run("property", happyTestCase{
 			input: "..name",
@@ -867,3 +1004,54 @@ func TestParser_Parse_HappyPath(t *testing.T) {
 		})
 	})
 }
+
+func TestParser_Spans(t *testing.T) {
+	parse := func(t *testing.T, input string) ast.Expr {
+		t.Helper()
+		tokens, err := lexer.NewTokenizer(input).Tokenize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parser.NewParser(tokens).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	t.Run("chained expressions", func(t *testing.T) {
+		got := parse(t, `foo["bar"].len($baz)`).(ast.ChainedExpr)
+		index := got.Exprs[0].(ast.ChainedExpr)
+		call := got.Exprs[1].(ast.CallExpr)
+		exp := []struct {
+			expr ast.Expr
+			span lexer.Span
+		}{
+			{expr: index.Exprs[0], span: lexer.Span{Pos: 0, Len: 3}},
+			{expr: index.Exprs[1], span: lexer.Span{Pos: 3, Len: 7}},
+			{expr: call, span: lexer.Span{Pos: 11, Len: 3}},
+			{expr: call.Args[0], span: lexer.Span{Pos: 15, Len: 4}},
+		}
+		for _, e := range exp {
+			span, ok := ast.SpanOf(e.expr)
+			if !ok || span != e.span {
+				t.Errorf("%s: expected span %+v, got %+v", ast.Describe(e.expr), e.span, span)
+			}
+		}
+	})
+
+	t.Run("unexpected end of selector", func(t *testing.T) {
+		tokens, err := lexer.NewTokenizer(`true ?`).Tokenize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = parser.NewParser(tokens).Parse()
+		var target *parser.UnexpectedTokenError
+		if !errors.As(err, &target) {
+			t.Fatalf("expected UnexpectedTokenError, got %v", err)
+		}
+		if span, _ := target.Span(); span.Pos != 6 {
+			t.Errorf("expected error at position 6, got %d", span.Pos)
+		}
+	})
+}
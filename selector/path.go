@@ -0,0 +1,32 @@
+package selector
+
+import (
+	"strconv"
+
+	"github.com/tomwright/dasel/v3/selector/lexer"
+)
+
+// PathRoot returns the path, or $root if the path is empty.
+func PathRoot(path string) string {
+	if path == "" {
+		return "$root"
+	}
+	return path
+}
+
+// PathKey returns the selector path to the given map key within path.
+// Keys that cannot be written as bare properties are quoted, e.g. $root["a b"].
+func PathKey(path string, key string) string {
+	if !lexer.IsSymbol(key) {
+		return PathRoot(path) + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// PathIndex returns the selector path to the given slice index within path.
+func PathIndex(path string, index int) string {
+	return PathRoot(path) + "[" + strconv.Itoa(index) + "]"
+}
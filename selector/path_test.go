@@ -0,0 +1,27 @@
+package selector_test
+
+import (
+	"testing"
+
+	"github.com/tomwright/dasel/v3/selector"
+)
+
+func TestPath(t *testing.T) {
+	testCases := []struct {
+		got string
+		exp string
+	}{
+		{got: selector.PathRoot(""), exp: "$root"},
+		{got: selector.PathKey("", "name"), exp: "name"},
+		{got: selector.PathKey("users[0]", "name"), exp: "users[0].name"},
+		{got: selector.PathKey("", "team name"), exp: `$root["team name"]`},
+		{got: selector.PathKey("labels", "a.b"), exp: `labels["a.b"]`},
+		{got: selector.PathIndex("", 1), exp: "$root[1]"},
+		{got: selector.PathIndex("users", 2), exp: "users[2]"},
+	}
+	for _, tc := range testCases {
+		if tc.got != tc.exp {
+			t.Errorf("expected %q, got %q", tc.exp, tc.got)
+		}
+	}
+}